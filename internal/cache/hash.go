@@ -165,6 +165,14 @@ func FileHash(file string) ([HashSize]byte, error) {
 	return out, nil
 }
 
+// ForgetFileHash makes FileHash calculate the hash of the file again:
+// it's needed when the file was changed.
+func ForgetFileHash(file string) {
+	hashFileCache.Lock()
+	delete(hashFileCache.m, file)
+	hashFileCache.Unlock()
+}
+
 // SetFileHash sets the hash returned by FileHash for file.
 func SetFileHash(file string, sum [HashSize]byte) {
 	hashFileCache.Lock()
@@ -2,6 +2,7 @@ package pkgcache
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"encoding/gob"
 	"encoding/hex"
 	"fmt"
@@ -27,6 +28,7 @@ type Cache struct {
 	sw            *timeutils.Stopwatch
 	log           logutils.Log  // not used now, but may be needed for future debugging purposes
 	ioSem         chan struct{} // semaphore limiting parallel IO
+	overlay       map[string][]byte
 }
 
 func NewCache(sw *timeutils.Stopwatch, log logutils.Log) (*Cache, error) {
@@ -42,6 +44,21 @@ func NewCache(sw *timeutils.Stopwatch, log logutils.Log) (*Cache, error) {
 	}, nil
 }
 
+// SetOverlay sets contents replacing contents of files on disk: they are
+// used instead of the files for calculation of package hashes.
+func (c *Cache) SetOverlay(overlay map[string][]byte) {
+	c.overlay = overlay
+}
+
+// Reset prepares the cache for the next loading of packages in the same process:
+// it forgets hashes of previously loaded packages and of the changed files.
+func (c *Cache) Reset(changedFiles []string) {
+	c.pkgHashes = sync.Map{}
+	for _, f := range changedFiles {
+		cache.ForgetFileHash(f)
+	}
+}
+
 func (c *Cache) Trim() {
 	c.sw.TrackStage("trim", func() {
 		c.lowLevelCache.Trim()
@@ -144,6 +161,11 @@ func (c *Cache) packageHash(pkg *packages.Package) (string, error) {
 	key := cache.NewHash("package hash")
 	fmt.Fprintf(key, "pkgpath %s\n", pkg.PkgPath)
 	for _, f := range pkg.CompiledGoFiles {
+		if content, ok := c.overlay[f]; ok {
+			fmt.Fprintf(key, "file %s %x\n", f, sha256.Sum256(content))
+			continue
+		}
+
 		c.ioSem <- struct{}{}
 		h, err := cache.FileHash(f)
 		<-c.ioSem
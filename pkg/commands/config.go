@@ -1,13 +1,19 @@
 package commands
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
 
+	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 
+	"github.com/golangci/golangci-lint/pkg/config"
 	"github.com/golangci/golangci-lint/pkg/exitcodes"
 	"github.com/golangci/golangci-lint/pkg/fsutils"
+	"github.com/golangci/golangci-lint/pkg/logutils"
 
 	"github.com/spf13/cobra"
 )
@@ -28,17 +34,91 @@ func (e *Executor) initConfig() {
 	e.rootCmd.AddCommand(cmd)
 
 	pathCmd := &cobra.Command{
-		Use:   "path",
-		Short: "Print used config path",
+		Use:   "path [dir]",
+		Short: "Print used config path and nested config files applied to packages of the directory",
 		Run:   e.executePathCmd,
 	}
 	e.initRunConfiguration(pathCmd) // allow --config
 	cmd.AddCommand(pathCmd)
+
+	printCmd := &cobra.Command{
+		Use:   "print",
+		Short: "Print the used config merged with configs it extends and origins of values",
+		Run:   e.executePrintCmd,
+	}
+	e.initRunConfiguration(printCmd) // allow --config
+	cmd.AddCommand(printCmd)
+
+	e.initConfigInit(cmd)
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "schema",
+		Short: "Print JSON Schema of config files",
+		Run:   e.executeSchemaCmd,
+	})
 }
 
 func (e *Executor) executePathCmd(_ *cobra.Command, args []string) {
+	if len(args) > 1 {
+		e.log.Fatalf("Usage: golangci-lint config path [dir]")
+	}
+
+	usedConfigFile := viper.ConfigFileUsed()
+	var nestedConfigFiles []string
+	if len(args) != 0 && !e.cfg.Run.NoConfig {
+		var err error
+		if nestedConfigFiles, err = findNestedConfigFiles(usedConfigFile, args[0]); err != nil {
+			e.log.Fatalf("Can't find nested config files: %s", err)
+		}
+	}
+
+	if usedConfigFile == "" && len(nestedConfigFiles) == 0 {
+		e.log.Warnf("No config file detected")
+		os.Exit(exitcodes.NoConfigFileDetected)
+	}
+
+	if usedConfigFile != "" {
+		fmt.Println(e.prettyConfigPath(usedConfigFile))
+	}
+	for _, configFile := range nestedConfigFiles {
+		fmt.Printf("%s (overrides issues and linters-settings)\n", e.prettyConfigPath(configFile))
+	}
+	os.Exit(0)
+}
+
+// findNestedConfigFiles returns nested config files applied to packages of the directory from the outer one
+func findNestedConfigFiles(usedConfigFile, dir string) ([]string, error) {
+	rootDir, err := nestedConfigsRootDir(usedConfigFile)
+	if err != nil {
+		return nil, err
+	}
+
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	if !fsutils.IsDir(absDir) {
+		return nil, fmt.Errorf("directory %s doesn't exist", dir)
+	}
+	if absDir, err = fsutils.EvalSymlinks(absDir); err != nil {
+		return nil, err
+	}
+
+	return config.NestedConfigFiles(rootDir, absDir), nil
+}
+
+func (e *Executor) prettyConfigPath(configFile string) string {
+	path, err := fsutils.ShortestRelPath(configFile, "")
+	if err != nil {
+		e.log.Warnf("Can't pretty print config file path: %s", err)
+		return configFile
+	}
+	return path
+}
+
+func (e *Executor) executePrintCmd(_ *cobra.Command, args []string) {
 	if len(args) != 0 {
-		e.log.Fatalf("Usage: golangci-lint config path")
+		e.log.Fatalf("Usage: golangci-lint config print")
 	}
 
 	usedConfigFile := viper.ConfigFileUsed()
@@ -47,11 +127,47 @@ func (e *Executor) executePathCmd(_ *cobra.Command, args []string) {
 		os.Exit(exitcodes.NoConfigFileDetected)
 	}
 
-	usedConfigFile, err := fsutils.ShortestRelPath(usedConfigFile, "")
+	settings, err := config.ReadFileSettings(usedConfigFile)
 	if err != nil {
-		e.log.Warnf("Can't pretty print config file path: %s", err)
+		e.log.Fatalf("Can't read config: %s", err)
 	}
 
-	fmt.Println(usedConfigFile)
+	if err = settings.Write(logutils.StdOut); err != nil {
+		e.log.Fatalf("Can't print config: %s", err)
+	}
+	os.Exit(0)
+}
+
+func (e *Executor) executeSchemaCmd(_ *cobra.Command, args []string) {
+	if len(args) != 0 {
+		e.log.Fatalf("Usage: golangci-lint config schema")
+	}
+
+	// defining of flags sets their default values in the config
+	defaults := config.NewDefault()
+	initFlagSet(pflag.NewFlagSet("schema flag set", pflag.ContinueOnError), defaults, e.DBManager, false)
+
+	var linterNames []string
+	for _, lc := range e.DBManager.GetAllSupportedLinterConfigs() {
+		linterNames = append(linterNames, lc.AllNames()...)
+	}
+	sort.Strings(linterNames)
+
+	// custom and external linters are enabled by their names too: names of linters are only suggested
+	schema := config.JSONSchema(defaults, config.SchemaEnums{
+		"linters.presets": e.DBManager.AllPresets(),
+	}, config.SchemaEnums{
+		"linters.enable":               linterNames,
+		"linters.disable":              linterNames,
+		"linters-settings.timeouts":    linterNames,
+		"issues.exclude-rules.linters": linterNames,
+		"severity.rules.linters":       linterNames,
+	})
+
+	out, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		e.log.Fatalf("Can't marshal schema: %s", err)
+	}
+	fmt.Fprintln(logutils.StdOut, string(out))
 	os.Exit(0)
 }
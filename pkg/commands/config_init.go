@@ -0,0 +1,250 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/exitcodes"
+	"github.com/golangci/golangci-lint/pkg/lint/linter"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+// defaultInitPresets are presets enabled by config init if linters aren't chosen on command-line
+var defaultInitPresets = []string{linter.PresetBugs, linter.PresetUnused}
+
+type configInitOptions struct {
+	outputFile        string
+	force             bool
+	maxExcludeRules   int
+	minExcludedIssues int
+}
+
+func (e *Executor) initConfigInit(configCmd *cobra.Command) {
+	var opts configInitOptions
+	cmd := &cobra.Command{
+		Use:   "init",
+		Short: "Lint the code once and write a starter config excluding the most noisy linters in directories",
+		Run: func(_ *cobra.Command, args []string) {
+			e.executeInitCmd(&opts, args)
+		},
+	}
+	e.initRunConfiguration(cmd) // allow choosing linters and options of the analysis
+
+	fs := cmd.Flags()
+	fs.StringVar(&opts.outputFile, "output-file", ".golangci.yml", wh("Path of the config file to write"))
+	fs.BoolVar(&opts.force, "force", false, wh("Overwrite the config file if it exists"))
+	fs.IntVar(&opts.maxExcludeRules, "max-exclude-rules", 10,
+		wh("Maximum count of exclude rules for the most noisy linters in directories"))
+	fs.IntVar(&opts.minExcludedIssues, "min-excluded-issues", 10,
+		wh("Minimum count of issues of a linter in a directory to exclude them"))
+
+	configCmd.AddCommand(cmd)
+}
+
+func (e *Executor) executeInitCmd(opts *configInitOptions, args []string) {
+	if !opts.force {
+		if _, err := os.Stat(opts.outputFile); err == nil {
+			e.log.Fatalf("Config file %s already exists: use --force to overwrite it", opts.outputFile)
+		}
+	}
+	if usedConfigFile := viper.ConfigFileUsed(); usedConfigFile != "" {
+		e.log.Warnf("Config file %s is used for the analysis: run with --no-config to ignore it", usedConfigFile)
+	}
+
+	lc := &e.cfg.Linters
+	if len(lc.Enable) == 0 && len(lc.Presets) == 0 && !lc.EnableAll && !lc.DisableAll {
+		lc.Presets = defaultInitPresets
+	}
+
+	issues, err := e.runInitAnalysis(args)
+	if err != nil {
+		e.log.Fatalf("Running error: %s", err)
+	}
+
+	noisy, remaining := findNoisyLinterDirs(issues, opts.maxExcludeRules, opts.minExcludedIssues)
+	content := makeInitConfig(&e.cfg.Linters, noisy, remaining)
+	if err = ioutil.WriteFile(opts.outputFile, content, 0644); err != nil {
+		e.log.Fatalf("Can't write config file: %s", err)
+	}
+
+	fmt.Fprintf(logutils.StdOut, "Wrote %s: %d issues found, %d of them are excluded by %d rules\n",
+		opts.outputFile, len(issues), len(issues)-remaining.total, len(noisy))
+	os.Exit(exitcodes.Success)
+}
+
+// runInitAnalysis lints the code once without limits of reported issues
+func (e *Executor) runInitAnalysis(args []string) ([]result.Issue, error) {
+	ic := &e.cfg.Issues
+	ic.MaxIssuesPerLinter = 0
+	ic.MaxSameIssues = 0
+	ic.NeedFix = false
+
+	ctx, cancel := context.WithTimeout(context.Background(), e.cfg.Run.Deadline)
+	defer cancel()
+
+	if err := e.goenv.Discover(ctx); err != nil {
+		e.log.Warnf("Failed to discover go env: %s", err)
+	}
+
+	defer e.silenceOutput()()
+
+	issuesCh, err := e.runAnalysis(ctx, args, nil)
+	if err != nil {
+		if errors.Cause(err) == exitcodes.ErrNoGoFiles {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var issues []result.Issue
+	for i := range issuesCh {
+		issues = append(issues, i)
+	}
+	if ctx.Err() != nil {
+		return nil, errors.New("deadline exceeded: try increase it by passing --deadline option")
+	}
+	return issues, nil
+}
+
+// linterDir is issues of a linter in a directory
+type linterDir struct {
+	linter string
+	dir    string
+	count  int
+}
+
+// remainingIssues are issues not excluded by exclude rules of noisy linters in directories
+type remainingIssues struct {
+	total            int
+	maxPerLinter     int
+	maxPerLinterName string
+}
+
+// findNoisyLinterDirs returns at most maxCount linters in directories with the most issues:
+// there are at least minIssues issues of every of them
+func findNoisyLinterDirs(issues []result.Issue, maxCount, minIssues int) ([]linterDir, remainingIssues) {
+	counts := map[linterDir]int{}
+	for i := range issues {
+		key := linterDir{linter: issues[i].FromLinter, dir: filepath.Dir(issues[i].FilePath())}
+		counts[key]++
+	}
+
+	var all []linterDir
+	for ld, count := range counts {
+		ld.count = count
+		all = append(all, ld)
+	}
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].count != all[j].count {
+			return all[i].count > all[j].count
+		}
+		if all[i].linter != all[j].linter {
+			return all[i].linter < all[j].linter
+		}
+		return all[i].dir < all[j].dir
+	})
+
+	var noisy []linterDir
+	perLinter := map[string]int{}
+	for _, ld := range all {
+		if len(noisy) < maxCount && ld.count >= minIssues {
+			noisy = append(noisy, ld)
+			continue
+		}
+		perLinter[ld.linter] += ld.count
+	}
+
+	var remaining remainingIssues
+	for name, count := range perLinter {
+		remaining.total += count
+		if count > remaining.maxPerLinter || count == remaining.maxPerLinter && name < remaining.maxPerLinterName {
+			remaining.maxPerLinter, remaining.maxPerLinterName = count, name
+		}
+	}
+	return noisy, remaining
+}
+
+// dirPathRegexp returns the regexp of exclude rules matching files in the directory, but not in its subdirectories
+func dirPathRegexp(dir string) string {
+	sep := regexp.QuoteMeta(string(filepath.Separator))
+	if dir == "." {
+		return fmt.Sprintf("^[^%s]+$", sep)
+	}
+	return fmt.Sprintf("^%s%s[^%s]+$", regexp.QuoteMeta(dir), sep, sep)
+}
+
+func yamlSingleQuoted(s string) string {
+	return "'" + strings.Replace(s, "'", "''", -1) + "'"
+}
+
+func writeYAMLList(b *bytes.Buffer, indent, key string, values []string) {
+	if len(values) == 0 {
+		return
+	}
+
+	fmt.Fprintf(b, "%s%s:\n", indent, key)
+	for _, v := range values {
+		fmt.Fprintf(b, "%s  - %s\n", indent, v)
+	}
+}
+
+// makeInitConfig returns the content of the config enabling linters and excluding issues of noisy linters in directories
+func makeInitConfig(lc *config.Linters, noisy []linterDir, remaining remainingIssues) []byte {
+	var b bytes.Buffer
+	b.WriteString("# Generated by golangci-lint config init: issues of the most noisy linters in directories\n")
+	b.WriteString("# are excluded to start from a working config. Fix them and remove exclude rules over time.\n")
+	b.WriteString("# See https://github.com/golangci/golangci-lint#config-file for all options.\n\n")
+
+	b.WriteString("linters:\n")
+	if lc.EnableAll {
+		b.WriteString("  enable-all: true\n")
+	}
+	if lc.DisableAll {
+		b.WriteString("  disable-all: true\n")
+	}
+	if lc.Fast {
+		b.WriteString("  fast: true\n")
+	}
+	writeYAMLList(&b, "  ", "presets", lc.Presets)
+	writeYAMLList(&b, "  ", "enable", lc.Enable)
+	writeYAMLList(&b, "  ", "disable", lc.Disable)
+
+	b.WriteString("\nissues:\n")
+	if len(noisy) != 0 {
+		b.WriteString("  # linters with the most issues in directories (not including subdirectories)\n")
+		b.WriteString("  exclude-rules:\n")
+		for _, ld := range noisy {
+			fmt.Fprintf(&b, "    # %d issues of %s in %s\n", ld.count, ld.linter, ld.dir)
+			fmt.Fprintf(&b, "    - path: %s\n", yamlSingleQuoted(dirPathRegexp(ld.dir)))
+			fmt.Fprintf(&b, "      linters:\n        - %s\n", ld.linter)
+		}
+		b.WriteString("\n")
+	}
+
+	// the limit of issues per linter is big enough to report all not excluded issues
+	const defaultMaxIssuesPerLinter = 50
+	maxIssuesPerLinter := defaultMaxIssuesPerLinter
+	if remaining.maxPerLinter > maxIssuesPerLinter {
+		maxIssuesPerLinter = (remaining.maxPerLinter + 9) / 10 * 10
+	}
+	if remaining.maxPerLinterName != "" {
+		fmt.Fprintf(&b, "  # not excluded issues: %d, the most of them are of %s: %d; all of them are reported\n",
+			remaining.total, remaining.maxPerLinterName, remaining.maxPerLinter)
+	}
+	fmt.Fprintf(&b, "  max-issues-per-linter: %d\n", maxIssuesPerLinter)
+
+	return b.Bytes()
+}
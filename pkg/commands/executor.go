@@ -1,12 +1,18 @@
 package commands
 
 import (
+	"crypto/sha256"
+	"io"
+	"os"
+
 	"github.com/fatih/color"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
 	"github.com/golangci/golangci-lint/pkg/golinters/goanalysis/load"
 
+	"github.com/golangci/golangci-lint/internal/cache"
 	"github.com/golangci/golangci-lint/internal/pkgcache"
 	"github.com/golangci/golangci-lint/pkg/timeutils"
 
@@ -18,11 +24,14 @@ import (
 	"github.com/golangci/golangci-lint/pkg/lint/lintersdb"
 	"github.com/golangci/golangci-lint/pkg/logutils"
 	"github.com/golangci/golangci-lint/pkg/report"
+	"github.com/golangci/golangci-lint/pkg/result/processors"
 )
 
 type Executor struct {
-	rootCmd *cobra.Command
-	runCmd  *cobra.Command
+	rootCmd  *cobra.Command
+	runCmd   *cobra.Command
+	serveCmd *cobra.Command
+	lspCmd   *cobra.Command
 
 	exitCode              int
 	version, commit, date string
@@ -37,6 +46,7 @@ type Executor struct {
 	fileCache         *fsutils.FileCache
 	lineCache         *fsutils.LineCache
 	pkgCache          *pkgcache.Cache
+	fixer             *processors.Fixer
 	debugf            logutils.DebugFunc
 	sw                *timeutils.Stopwatch
 
@@ -81,6 +91,8 @@ func NewExecutor(version, commit, date string) *Executor {
 	// init sets config with the default values of flags
 	e.initRoot()
 	e.initRun()
+	e.initServe()
+	e.initLSP()
 	e.initHelp()
 	e.initLinters()
 	e.initConfig()
@@ -97,6 +109,9 @@ func NewExecutor(version, commit, date string) *Executor {
 
 	// recreate after getting config
 	e.DBManager = lintersdb.NewManager(e.cfg)
+	if err = e.DBManager.LoadCustomLinters(); err != nil {
+		e.log.Fatalf("Can't load custom linters: %s", err)
+	}
 
 	e.cfg.LintersSettings.Gocritic.InferEnabledChecks(e.log)
 	if err = e.cfg.LintersSettings.Gocritic.Validate(e.log); err != nil {
@@ -105,6 +120,8 @@ func NewExecutor(version, commit, date string) *Executor {
 
 	// Slice options must be explicitly set for proper merging of config and command-line options.
 	fixSlicesFlags(e.runCmd.Flags())
+	fixSlicesFlags(e.serveCmd.Flags())
+	fixSlicesFlags(e.lspCmd.Flags())
 
 	e.EnabledLintersSet = lintersdb.NewEnabledSet(e.DBManager,
 		lintersdb.NewValidator(e.DBManager), e.log.Child("lintersdb"), e.cfg)
@@ -112,6 +129,10 @@ func NewExecutor(version, commit, date string) *Executor {
 	e.fileCache = fsutils.NewFileCache()
 	e.lineCache = fsutils.NewLineCache(e.fileCache)
 
+	if err = initHashSalt(version); err != nil {
+		e.log.Fatalf("Failed to init hash salt: %s", err)
+	}
+
 	e.sw = timeutils.NewStopwatch("pkgcache", e.log.Child("stopwatch"))
 	e.pkgCache, err = pkgcache.NewCache(e.sw, e.log.Child("pkgcache"))
 	if err != nil {
@@ -127,3 +148,32 @@ func NewExecutor(version, commit, date string) *Executor {
 func (e *Executor) Execute() error {
 	return e.rootCmd.Execute()
 }
+
+// initHashSalt makes cached data of different golangci-lint versions independent:
+// a new version can change issues of linters or facts of analyzers.
+func initHashSalt(version string) error {
+	if version != "" && version != "master" {
+		cache.SetSalt([]byte(version))
+		return nil
+	}
+
+	// it's a development build: the version doesn't identify the code
+	binPath, err := os.Executable()
+	if err != nil {
+		return errors.Wrap(err, "failed to get executable path")
+	}
+
+	f, err := os.Open(binPath)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open executable %s", binPath)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return errors.Wrapf(err, "failed to calculate hash of executable %s", binPath)
+	}
+
+	cache.SetSalt(h.Sum(nil))
+	return nil
+}
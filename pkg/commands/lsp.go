@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/golangci/golangci-lint/pkg/daemon"
+	"github.com/golangci/golangci-lint/pkg/exitcodes"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/lsp"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func (e *Executor) initLSP() {
+	e.lspCmd = &cobra.Command{
+		Use:   "lsp",
+		Short: "Run a language server publishing issues as diagnostics",
+		Long: "Run a language server speaking the Language Server Protocol over stdin and stdout. " +
+			"It lints opened files on every change and provides code actions to fix issues or to disable a linter " +
+			"for a line. Start it in the root directory of the project.",
+		Run: e.executeLSP,
+	}
+	e.rootCmd.AddCommand(e.lspCmd)
+
+	e.lspCmd.SetOutput(logutils.StdOut) // use custom output to properly color it in Windows terminals
+	e.initRunConfiguration(e.lspCmd)    // the language server lints with options of the run command
+}
+
+func (e *Executor) executeLSP(_ *cobra.Command, args []string) {
+	if len(args) != 0 {
+		e.log.Fatalf("Usage: golangci-lint lsp")
+	}
+
+	shutdown, err := e.serveLSP()
+	if err != nil {
+		e.log.Fatalf("Language server error: %s", err)
+	}
+
+	if !shutdown { // the client exited without the shutdown request
+		e.exitCode = exitcodes.Failure
+	}
+}
+
+func (e *Executor) serveLSP() (bool, error) {
+	ctx := context.Background()
+
+	d, err := e.newLintDaemon(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer d.Close()
+
+	lint := func(ctx context.Context, path string, overlay map[string][]byte) ([]result.Issue, error) {
+		resp := d.lint(ctx, &daemon.Request{
+			Wd:            d.wd,
+			StdinFilename: path,
+			Overlay:       overlay,
+		})
+		if resp.Error != "" {
+			return nil, errors.New(resp.Error)
+		}
+		return resp.Issues, nil
+	}
+
+	// stdout is the protocol channel: save it before silencing output of linters
+	stdout := os.Stdout
+	defer e.silenceOutput()()
+
+	server := lsp.NewServer(d.wd, lint, d.isConfigFile, d.configChanged, e.log.Child("lsp"))
+	return server.Run(ctx, os.Stdin, stdout)
+}
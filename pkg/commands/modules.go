@@ -0,0 +1,375 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/exitcodes"
+	"github.com/golangci/golangci-lint/pkg/fsutils"
+	"github.com/golangci/golangci-lint/pkg/golinters/goanalysis/load"
+	"github.com/golangci/golangci-lint/pkg/lint"
+	"github.com/golangci/golangci-lint/pkg/lint/astcache"
+	"github.com/golangci/golangci-lint/pkg/lint/linter"
+	"github.com/golangci/golangci-lint/pkg/lint/lintersdb"
+	"github.com/golangci/golangci-lint/pkg/result"
+	"github.com/golangci/golangci-lint/pkg/result/processors"
+)
+
+// lintGroup is a group of targets linted with the same config: modules having
+// own config files are linted in own groups
+type lintGroup struct {
+	configFile        string // the config file of the group, empty if there is no config file
+	cfg               *config.Config
+	dbManager         *lintersdb.Manager
+	contextLoader     *lint.ContextLoader
+	enabledLinters    []*linter.Config
+	enabledLintersMap map[string]*linter.Config
+	targets           []lint.Target
+}
+
+// runLintGroups lints modules of run.modules and combinations of run.build-matrix:
+// issues of all of them are merged into one report. Groups share the baseline, the diff
+// and limits of issues count configured by the root config and command-line options.
+func (e *Executor) runLintGroups(ctx context.Context, enabledLinters []*linter.Config,
+	enabledLintersMap map[string]*linter.Config) (<-chan result.Issue, error) {
+	groups, err := e.makeLintGroups(enabledLinters, enabledLintersMap)
+	if err != nil {
+		return nil, err
+	}
+
+	shared, err := lint.NewSharedProcessors(e.cfg, e.lineCache, e.log.Child("runner"))
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []result.Issue
+	var isLoaded bool
+	for _, g := range groups {
+		// groups are linted one by one: loading changes the global build context
+		groupIssues, err := e.runLintGroup(ctx, g, shared)
+		if err != nil {
+			if errors.Cause(err) == exitcodes.ErrNoGoFiles {
+				continue
+			}
+			return nil, err
+		}
+
+		isLoaded = true
+		issues = append(issues, groupIssues...)
+	}
+	if !isLoaded {
+		return nil, exitcodes.ErrNoGoFiles
+	}
+	shared.Finish()
+
+	issuesCh := make(chan result.Issue, len(issues))
+	for _, i := range issues {
+		issuesCh <- i
+	}
+	close(issuesCh)
+
+	e.fixer = processors.NewFixer(e.cfg, e.log, e.fileCache)
+	return e.fixer.Process(issuesCh), nil
+}
+
+func (e *Executor) runLintGroup(ctx context.Context, g *lintGroup,
+	shared *lint.SharedProcessors) ([]result.Issue, error) {
+	var builds []lint.BuildContext
+	var astCaches []*astcache.Cache
+	for i := range g.targets {
+		t := &g.targets[i]
+		lintCtx, err := g.contextLoader.LoadTarget(ctx, g.enabledLinters, t)
+		if err != nil {
+			if errors.Cause(err) == exitcodes.ErrNoGoFiles {
+				e.log.Infof("No go files to lint for %s", t)
+				continue
+			}
+			return nil, errors.Wrapf(err, "context loading for %s failed", t)
+		}
+		lintCtx.Log = e.log.Child("linters context")
+
+		builds = append(builds, lint.BuildContext{Name: t.Build.String(), LintCtx: lintCtx})
+		astCaches = append(astCaches, lintCtx.ASTCache)
+	}
+	if len(builds) == 0 {
+		return nil, exitcodes.ErrNoGoFiles
+	}
+
+	astCache := astcache.Merge(e.log.Child("astcache"), astCaches...)
+	runner, err := lint.NewRunner(astCache, g.cfg, e.log.Child("runner"),
+		e.goenv, e.lineCache, g.dbManager, g.enabledLintersMap)
+	if err != nil {
+		return nil, err
+	}
+	runner.ShareProcessors(shared)
+	if err = e.useNestedConfigs(runner, g.configFile); err != nil {
+		return nil, err
+	}
+
+	var issues []result.Issue
+	for i := range runner.RunBuildMatrix(ctx, g.enabledLinters, builds) {
+		issues = append(issues, i)
+	}
+	return issues, nil
+}
+
+func (e *Executor) makeLintGroups(enabledLinters []*linter.Config,
+	enabledLintersMap map[string]*linter.Config) ([]*lintGroup, error) {
+	root := &lintGroup{
+		configFile:        viper.ConfigFileUsed(),
+		cfg:               e.cfg,
+		dbManager:         e.DBManager,
+		contextLoader:     e.contextLoader,
+		enabledLinters:    enabledLinters,
+		enabledLintersMap: enabledLintersMap,
+	}
+
+	modules, err := e.modulesToLint()
+	if err != nil {
+		return nil, err
+	}
+	if len(modules) == 0 {
+		root.targets = buildTargets(lint.Target{}, e.cfg.Run.BuildMatrix)
+		return []*lintGroup{root}, nil
+	}
+
+	wd, err := fsutils.Getwd()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get working directory")
+	}
+
+	groups := []*lintGroup{root}
+	groupByConfigFile := map[string]*lintGroup{"": root}
+	for _, m := range modules {
+		args, err := e.contextLoader.ModuleArgs(m, modules)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to build args of module %s", m)
+		}
+		if len(args) == 0 {
+			continue // no packages of the module are linted
+		}
+
+		configFile := e.findModuleConfigFile(m, wd)
+		g := groupByConfigFile[configFile]
+		if g == nil {
+			if g, err = e.newModuleLintGroup(configFile); err != nil {
+				return nil, errors.Wrapf(err, "failed to read config of module %s", m)
+			}
+			groups = append(groups, g)
+			groupByConfigFile[configFile] = g
+		}
+
+		g.targets = append(g.targets, buildTargets(lint.Target{Dir: m, Args: args}, g.cfg.Run.BuildMatrix)...)
+	}
+
+	return groups, nil
+}
+
+// buildTargets returns the target for every combination of the build matrix
+func buildTargets(t lint.Target, matrix []config.BuildCombination) []lint.Target {
+	if len(matrix) == 0 {
+		return []lint.Target{t}
+	}
+
+	ret := make([]lint.Target, 0, len(matrix))
+	for _, b := range matrix {
+		t.Build = b
+		ret = append(ret, t)
+	}
+	return ret
+}
+
+// modulesToLint returns absolute directories of modules from run.modules or found
+// by run.discover-modules: it returns nil if modules aren't linted separately.
+func (e *Executor) modulesToLint() ([]string, error) {
+	rc := &e.cfg.Run
+	if len(rc.Modules) == 0 && !rc.DiscoverModules {
+		return nil, nil
+	}
+	if len(rc.Modules) != 0 && rc.DiscoverModules {
+		return nil, errors.New("options run.modules and run.discover-modules can't be combined")
+	}
+
+	if rc.DiscoverModules {
+		wd, err := fsutils.Getwd()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get working directory")
+		}
+
+		modules, err := lint.DiscoverModules(wd)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to discover modules")
+		}
+		e.log.Infof("Discovered modules: %s", modules)
+		return modules, nil
+	}
+
+	var modules []string
+	for _, m := range rc.Modules {
+		absPath, err := filepath.Abs(m)
+		if err != nil {
+			return nil, err
+		}
+		if !fsutils.IsDir(absPath) {
+			return nil, fmt.Errorf("module directory %s doesn't exist", m)
+		}
+
+		if absPath, err = fsutils.EvalSymlinks(absPath); err != nil {
+			return nil, err
+		}
+		modules = append(modules, absPath)
+	}
+	return modules, nil
+}
+
+// findModuleConfigFile returns the nearest config file in the module directory or its parents
+// inside the working directory: an empty string means the module uses the root config.
+func (e *Executor) findModuleConfigFile(module, wd string) string {
+	if e.cfg.Run.NoConfig {
+		return ""
+	}
+
+	rootConfigFile, _ := filepath.Abs(viper.ConfigFileUsed())
+	for dir := module; dir != wd; dir = filepath.Dir(dir) {
+		if configFile := config.FindConfigFile(dir); configFile != "" {
+			if configFile == rootConfigFile {
+				return ""
+			}
+			return configFile
+		}
+
+		if !strings.HasPrefix(dir, wd+string(filepath.Separator)) {
+			break // the module is outside of the working directory: only its own config is used
+		}
+	}
+
+	return ""
+}
+
+func (e *Executor) newModuleLintGroup(configFile string) (*lintGroup, error) {
+	cfg, err := e.readModuleConfig(configFile)
+	if err != nil {
+		return nil, err
+	}
+
+	dbManager := lintersdb.NewManager(cfg)
+	if err = dbManager.LoadCustomLinters(); err != nil {
+		return nil, errors.Wrap(err, "can't load custom linters")
+	}
+
+	enabledLintersSet := lintersdb.NewEnabledSet(dbManager,
+		lintersdb.NewValidator(dbManager), e.log.Child("lintersdb"), cfg)
+	enabledLintersMap, err := enabledLintersSet.GetEnabledLintersMap()
+	if err != nil {
+		return nil, err
+	}
+	enabledLinters, err := enabledLintersSet.Get(true)
+	if err != nil {
+		return nil, err
+	}
+
+	contextLoader := lint.NewContextLoader(cfg, e.log.Child("loader"), e.goenv,
+		e.lineCache, e.fileCache, e.pkgCache, load.NewGuard())
+
+	return &lintGroup{
+		configFile:        configFile,
+		cfg:               cfg,
+		dbManager:         dbManager,
+		contextLoader:     contextLoader,
+		enabledLinters:    enabledLinters,
+		enabledLintersMap: enabledLintersMap,
+	}, nil
+}
+
+// readModuleConfig reads the config file of a module: command-line options
+// override it as they override the root config
+func (e *Executor) readModuleConfig(configFile string) (*config.Config, error) {
+	return e.readConfig(func(r *config.FileReader) error {
+		return r.ReadFile(configFile)
+	})
+}
+
+// readNestedConfig reads the root config file overridden by nested config files:
+// command-line options override them as they override the root config
+func (e *Executor) readNestedConfig(rootConfigFile string, nestedConfigFiles []string) (*config.Config, error) {
+	return e.readConfig(func(r *config.FileReader) error {
+		return r.ReadNestedFiles(rootConfigFile, nestedConfigFiles)
+	})
+}
+
+// useNestedConfigs makes the runner lint packages with nested config files in subdirectories
+// of the directory of its config file or of the working directory if there is no config file
+func (e *Executor) useNestedConfigs(runner *lint.Runner, configFile string) error {
+	if e.cfg.Run.NoConfig {
+		return nil
+	}
+
+	rootDir, err := nestedConfigsRootDir(configFile)
+	if err != nil {
+		return err
+	}
+	runner.UseNestedConfigs(rootDir, func(nestedConfigFiles []string) (*config.Config, error) {
+		return e.readNestedConfig(configFile, nestedConfigFiles)
+	})
+	return nil
+}
+
+// nestedConfigsRootDir returns the absolute directory of the config file or the working directory
+// if there is no config file: config files in its subdirectories are nested ones
+func nestedConfigsRootDir(configFile string) (string, error) {
+	if configFile == "" {
+		wd, err := fsutils.Getwd()
+		if err != nil {
+			return "", errors.Wrap(err, "failed to get working directory")
+		}
+		return wd, nil
+	}
+
+	absPath, err := filepath.Abs(filepath.Dir(configFile))
+	if err != nil {
+		return "", err
+	}
+	return fsutils.EvalSymlinks(absPath)
+}
+
+// readConfig reads the config by read: command-line options override it
+func (e *Executor) readConfig(read func(r *config.FileReader) error) (*config.Config, error) {
+	cfg := config.NewDefault()
+
+	// defining of flags sets their default values in the config
+	fs := pflag.NewFlagSet("module config flag set", pflag.ContinueOnError)
+	initFlagSet(fs, cfg, e.DBManager, false)
+	initRootFlagSet(fs, cfg, true)
+
+	r := config.NewFileReader(cfg, e.cfg, e.log.Child("config_reader"))
+	if err := read(r); err != nil {
+		return nil, err
+	}
+
+	fixSlicesFlags(fs)
+	fs.Usage = func() {} // otherwise help text will be printed
+	fs.ParseErrorsWhitelist.UnknownFlags = true
+	if err := fs.Parse(os.Args); err != nil {
+		return nil, fmt.Errorf("can't parse args: %s", err)
+	}
+
+	// options of the current run aren't read from command-line, e.g. in the daemon mode
+	cfg.Run.Args = e.cfg.Run.Args
+	cfg.Run.Files = e.cfg.Run.Files
+	cfg.Run.StdinFilename = e.cfg.Run.StdinFilename
+
+	cfg.LintersSettings.Gocritic.InferEnabledChecks(e.log)
+	if err := cfg.LintersSettings.Gocritic.Validate(e.log); err != nil {
+		return nil, fmt.Errorf("invalid gocritic settings: %s", err)
+	}
+
+	return cfg, nil
+}
@@ -3,6 +3,7 @@ package commands
 import (
 	"context"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"log"
 	"os"
@@ -17,10 +18,14 @@ import (
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
 
 	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/daemon"
 	"github.com/golangci/golangci-lint/pkg/exitcodes"
+	"github.com/golangci/golangci-lint/pkg/fsutils"
 	"github.com/golangci/golangci-lint/pkg/lint"
+	"github.com/golangci/golangci-lint/pkg/lint/linter"
 	"github.com/golangci/golangci-lint/pkg/lint/lintersdb"
 	"github.com/golangci/golangci-lint/pkg/logutils"
 	"github.com/golangci/golangci-lint/pkg/printers"
@@ -75,7 +80,9 @@ func initFlagSet(fs *pflag.FlagSet, cfg *config.Config, m *lintersdb.Manager, is
 	oc := &cfg.Output
 	fs.StringVar(&oc.Format, "out-format",
 		config.OutFormatColoredLineNumber,
-		wh(fmt.Sprintf("Format of output: %s", strings.Join(config.OutFormats, "|"))))
+		wh(fmt.Sprintf("Formats of output separated by comma: %s. "+
+			"Append :path to a format to write it into the file: only one format is printed to stdout",
+			strings.Join(config.OutFormats, "|"))))
 	fs.BoolVar(&oc.PrintIssuedLine, "print-issued-lines", true, wh("Print lines of code with issue"))
 	fs.BoolVar(&oc.PrintLinterName, "print-linter-name", true, wh("Print linter name in issue line"))
 	fs.BoolVar(&oc.PrintWelcomeMessage, "print-welcome", false, wh("Print welcome message"))
@@ -86,15 +93,32 @@ func initFlagSet(fs *pflag.FlagSet, cfg *config.Config, m *lintersdb.Manager, is
 	fs.IntVar(&rc.ExitCodeIfIssuesFound, "issues-exit-code",
 		exitcodes.IssuesFound, wh("Exit code when issues were found"))
 	fs.StringSliceVar(&rc.BuildTags, "build-tags", nil, wh("Build tags"))
+	fs.StringSliceVar(&rc.Modules, "modules", nil,
+		wh("Directories of Go modules to lint separately: nested modules aren't linted by ./... of the parent one"))
+	fs.BoolVar(&rc.DiscoverModules, "discover-modules", false,
+		wh("Lint all Go modules found in the working directory and its subdirectories"))
 	fs.DurationVar(&rc.Deadline, "deadline", time.Minute, wh("Deadline for total work"))
 	fs.BoolVar(&rc.AnalyzeTests, "tests", true, wh("Analyze tests (*_test.go)"))
 	fs.BoolVar(&rc.PrintResourcesUsage, "print-resources-usage", false,
 		wh("Print avg and max memory usage of golangci-lint and total time"))
 	fs.StringVarP(&rc.Config, "config", "c", "", wh("Read config from file path `PATH`"))
 	fs.BoolVar(&rc.NoConfig, "no-config", false, wh("Don't read config"))
+	fs.BoolVar(&rc.StrictConfig, "strict-config", false,
+		wh("Fail if config files have unknown keys instead of printing warnings"))
 	fs.StringSliceVar(&rc.SkipDirs, "skip-dirs", nil, wh("Regexps of directories to skip"))
 	fs.BoolVar(&rc.UseDefaultSkipDirs, "skip-dirs-use-default", true, getDefaultDirectoryExcludeHelp())
 	fs.StringSliceVar(&rc.SkipFiles, "skip-files", nil, wh("Regexps of files to skip"))
+	fs.BoolVar(&rc.Files, "files", false,
+		wh("Treat arguments as files: lint their whole packages, then filter out issues of other files"))
+	fs.StringVar(&rc.StdinFilename, "stdin-filename", "",
+		wh("Lint source code from stdin as the file `PATH` and report issues only for it"))
+	fs.BoolVar(&rc.Daemon, "daemon", false,
+		wh("Send the lint request to the daemon started by golangci-lint serve in the same directory"))
+	fs.StringVar(&rc.DaemonSocket, "daemon-socket", "",
+		wh("Path of the unix socket of the daemon (default is unique for the working directory)"))
+	fs.StringVar(&rc.Overlay, "overlay", "",
+		wh("Read contents of files from JSON `FILE` in the format of go build -overlay: "+
+			`{"Replace": {"file.go": "replacement.go"}}`))
 
 	// Linters settings config
 	lsc := &cfg.LintersSettings
@@ -191,7 +215,13 @@ func initFlagSet(fs *pflag.FlagSet, cfg *config.Config, m *lintersdb.Manager, is
 		wh("Show only new issues created after git revision `REV`"))
 	fs.StringVar(&ic.DiffPatchFilePath, "new-from-patch", "",
 		wh("Show only new issues created in git patch with file path `PATH`"))
+	fs.StringVar(&ic.BaselinePath, "baseline", "",
+		wh("Hide issues recorded in the baseline file with file path `PATH`"))
+	fs.StringVar(&ic.WriteBaselinePath, "write-baseline", "",
+		wh("Record all found issues into the baseline file with file path `PATH` instead of showing them"))
 	fs.BoolVar(&ic.NeedFix, "fix", false, "Fix found issues (if it's supported by the linter)")
+	fs.BoolVar(&ic.FixDiff, "fix-diff", false,
+		wh("Don't apply fixes of --fix: print them as a unified diff and exit with issues exit code if there are any fixes"))
 }
 
 func (e *Executor) initRunConfiguration(cmd *cobra.Command) {
@@ -218,6 +248,8 @@ func (e *Executor) getConfigForCommandLine() (*config.Config, error) {
 	initRootFlagSet(fs, &cfg, true)
 
 	fs.Usage = func() {} // otherwise help text will be printed twice
+	// flags of commands, e.g. of config init, aren't defined here: cobra checks them
+	fs.ParseErrorsWhitelist.UnknownFlags = true
 	if err := fs.Parse(os.Args); err != nil {
 		if err == pflag.ErrHelp {
 			return nil, err
@@ -265,25 +297,36 @@ func fixSlicesFlags(fs *pflag.FlagSet) {
 	})
 }
 
-func (e *Executor) runAnalysis(ctx context.Context, args []string) (<-chan result.Issue, error) {
+// runAnalysis lints packages from args: contents of files from the overlay are used instead of files on disk
+func (e *Executor) runAnalysis(ctx context.Context, args []string, overlay map[string][]byte) (<-chan result.Issue, error) {
 	e.cfg.Run.Args = args
 
+	if e.cfg.Issues.FixDiff && !e.cfg.Issues.NeedFix {
+		return nil, errors.New("option --fix-diff requires option --fix")
+	}
+
+	e.fileCache.SetOverlay(overlay)
+	e.pkgCache.SetOverlay(overlay)
+
+	enabledLintersMap, err := e.EnabledLintersSet.GetEnabledLintersMap()
+	if err != nil {
+		return nil, err
+	}
+
 	enabledLinters, err := e.EnabledLintersSet.Get(true)
 	if err != nil {
 		return nil, err
 	}
 
 	for _, lc := range e.DBManager.GetAllSupportedLinterConfigs() {
-		isEnabled := false
-		for _, enabledLC := range enabledLinters {
-			if enabledLC.Name() == lc.Name() {
-				isEnabled = true
-				break
-			}
-		}
+		isEnabled := enabledLintersMap[lc.Name()] != nil
 		e.reportData.AddLinter(lc.Name(), isEnabled, lc.EnabledByDefault)
 	}
 
+	if rc := &e.cfg.Run; len(rc.BuildMatrix) != 0 || len(rc.Modules) != 0 || rc.DiscoverModules {
+		return e.runLintGroups(ctx, enabledLinters, enabledLintersMap)
+	}
+
 	lintCtx, err := e.contextLoader.Load(ctx, enabledLinters)
 	if err != nil {
 		return nil, errors.Wrap(err, "context loading failed")
@@ -291,14 +334,137 @@ func (e *Executor) runAnalysis(ctx context.Context, args []string) (<-chan resul
 	lintCtx.Log = e.log.Child("linters context")
 
 	runner, err := lint.NewRunner(lintCtx.ASTCache, e.cfg, e.log.Child("runner"),
-		e.goenv, e.lineCache, e.DBManager)
+		e.goenv, e.lineCache, e.DBManager, enabledLintersMap)
 	if err != nil {
 		return nil, err
 	}
+	if err = e.useNestedConfigs(runner, viper.ConfigFileUsed()); err != nil {
+		return nil, err
+	}
 
 	issuesCh := runner.Run(ctx, enabledLinters, lintCtx)
-	fixer := processors.NewFixer(e.cfg, e.log, e.fileCache)
-	return fixer.Process(issuesCh), nil
+	e.fixer = processors.NewFixer(e.cfg, e.log, e.fileCache)
+	return e.fixer.Process(issuesCh), nil
+}
+
+// readOverlay reads contents of files from option --overlay and source code from stdin
+// (as the file from option --stdin-filename): they are used instead of contents of files on disk
+func (e *Executor) readOverlay(args []string) (map[string][]byte, error) {
+	rc := &e.cfg.Run
+	if rc.Overlay == "" && rc.StdinFilename == "" {
+		return nil, nil
+	}
+
+	if rc.StdinFilename != "" && (len(args) != 0 || rc.Files) {
+		return nil, errors.New("option --stdin-filename can't be used with arguments or option --files")
+	}
+	if e.cfg.Issues.NeedFix && !e.cfg.Issues.FixDiff {
+		return nil, errors.New("option --fix can't be used with --stdin-filename or --overlay: use --fix-diff")
+	}
+
+	overlay := map[string][]byte{}
+	if rc.Overlay != "" {
+		var err error
+		if overlay, err = fsutils.ReadOverlayFile(rc.Overlay); err != nil {
+			return nil, err
+		}
+	}
+
+	if rc.StdinFilename != "" {
+		content, err := ioutil.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read stdin")
+		}
+
+		path, err := fsutils.OverlayPath(rc.StdinFilename)
+		if err != nil {
+			return nil, err
+		}
+		overlay[path] = content
+	}
+
+	return overlay, nil
+}
+
+// runAnalysisInDaemon sends the lint request to the daemon started by `golangci-lint serve`
+func (e *Executor) runAnalysisInDaemon(ctx context.Context, args []string,
+	overlay map[string][]byte) (<-chan result.Issue, error) {
+	if e.cfg.Issues.NeedFix {
+		return nil, errors.New("option --fix can't be used with --daemon")
+	}
+
+	wd, err := fsutils.Getwd()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get working directory")
+	}
+
+	socketPath := e.cfg.Run.DaemonSocket
+	if socketPath == "" {
+		socketPath = daemon.DefaultSocketPath(wd)
+	}
+
+	resp, err := daemon.Send(ctx, socketPath, &daemon.Request{
+		Wd:            wd,
+		Args:          args,
+		Files:         e.cfg.Run.Files,
+		StdinFilename: e.cfg.Run.StdinFilename,
+		Overlay:       overlay,
+		Flags:         changedFlags(e.runCmd.LocalFlags()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Error != "" {
+		if resp.ErrorCode != exitcodes.Success {
+			return nil, &exitcodes.ExitError{Message: resp.Error, Code: resp.ErrorCode}
+		}
+		return nil, errors.New(resp.Error)
+	}
+
+	if resp.Report != nil {
+		// log daemon warnings and errors to show them and to get the same exit code as without the daemon
+		for _, w := range resp.Report.Warnings {
+			e.log.Child(w.Tag).Warnf("%s", w.Text)
+		}
+		if resp.Report.Error != "" {
+			e.log.Errorf("%s", resp.Report.Error)
+		}
+		e.reportData.Linters = resp.Report.Linters
+	}
+
+	issues := make(chan result.Issue, len(resp.Issues))
+	for _, i := range resp.Issues {
+		issues <- i
+	}
+	close(issues)
+
+	return issues, nil
+}
+
+// changedFlags returns names of options set in the command line
+func changedFlags(fs *pflag.FlagSet) []string {
+	var ret []string
+	fs.VisitAll(func(f *pflag.Flag) { // Visit doesn't work for command local flag sets
+		if f.Changed {
+			ret = append(ret, f.Name)
+		}
+	})
+	return ret
+}
+
+// silenceOutput doesn't allow linters and loader to print anything:
+// it returns a function restoring the output.
+func (e *Executor) silenceOutput() func() {
+	if logutils.HaveDebugTag("linters_output") {
+		return func() {}
+	}
+
+	log.SetOutput(ioutil.Discard)
+	savedStdout, savedStderr := e.setOutputToDevNull()
+	return func() {
+		os.Stdout, os.Stderr = savedStdout, savedStderr
+	}
 }
 
 func (e *Executor) setOutputToDevNull() (savedStdout, savedStderr *os.File) {
@@ -323,7 +489,8 @@ func (e *Executor) setExitCodeIfIssuesFound(issues <-chan result.Issue) <-chan r
 			resCh <- i
 		}
 
-		if issuesFound {
+		// fixer closes issues channel only after making of all fixes
+		if issuesFound || e.fixer != nil && e.fixer.Diff() != "" {
 			e.exitCode = e.cfg.Run.ExitCodeIfIssuesFound
 		}
 
@@ -334,33 +501,45 @@ func (e *Executor) setExitCodeIfIssuesFound(issues <-chan result.Issue) <-chan r
 }
 
 func (e *Executor) runAndPrint(ctx context.Context, args []string) error {
-	if err := e.goenv.Discover(ctx); err != nil {
-		e.log.Warnf("Failed to discover go env: %s", err)
+	outputs, err := parseOutFormats(e.cfg.Output.Format)
+	if err != nil {
+		return err
 	}
 
-	if !logutils.HaveDebugTag("linters_output") {
-		// Don't allow linters and loader to print anything
-		log.SetOutput(ioutil.Discard)
-		savedStdout, savedStderr := e.setOutputToDevNull()
-		defer func() {
-			os.Stdout, os.Stderr = savedStdout, savedStderr
-		}()
+	overlay, err := e.readOverlay(args)
+	if err != nil {
+		return err
 	}
 
-	issues, err := e.runAnalysis(ctx, args)
-	if err != nil {
-		return err // XXX: don't loose type
+	if !e.cfg.Run.Daemon {
+		if err = e.goenv.Discover(ctx); err != nil {
+			e.log.Warnf("Failed to discover go env: %s", err)
+		}
 	}
 
-	p, err := e.createPrinter()
+	defer e.silenceOutput()()
+
+	var issues <-chan result.Issue
+	if e.cfg.Run.Daemon {
+		issues, err = e.runAnalysisInDaemon(ctx, args, overlay)
+	} else {
+		issues, err = e.runAnalysis(ctx, args, overlay)
+	}
 	if err != nil {
-		return err
+		return err // XXX: don't loose type
 	}
 
 	issues = e.setExitCodeIfIssuesFound(issues)
 
-	if err = p.Print(ctx, issues); err != nil {
-		return fmt.Errorf("can't print %d issues: %s", len(issues), err)
+	if err = e.printReports(ctx, outputs, issues); err != nil {
+		return err
+	}
+
+	// print the diff after issues to not mix them
+	if e.fixer != nil {
+		if diff := e.fixer.Diff(); diff != "" {
+			fmt.Fprint(logutils.StdOut, diff)
+		}
 	}
 
 	e.fileCache.PrintStats(e.log)
@@ -368,24 +547,141 @@ func (e *Executor) runAndPrint(ctx context.Context, args []string) error {
 	return nil
 }
 
-func (e *Executor) createPrinter() (printers.Printer, error) {
+// outFormat is an output format with a path of a file to write it, stdout is used if the path is empty
+type outFormat struct {
+	format string
+	path   string
+}
+
+// parseOutFormats parses comma-separated output formats like `colored-line-number,checkstyle:report.xml`:
+// only one of them can be printed to stdout, others are written to files
+func parseOutFormats(value string) ([]outFormat, error) {
+	var ret []outFormat
+	stdoutFormat := ""
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		var of outFormat
+		if colon := strings.Index(part, ":"); colon != -1 {
+			of.format, of.path = part[:colon], part[colon+1:]
+			if of.path == "" {
+				return nil, fmt.Errorf("empty file path for output format %s", of.format)
+			}
+		} else {
+			of.format = part
+		}
+
+		isKnownFormat := false
+		for _, f := range config.OutFormats {
+			if f == of.format {
+				isKnownFormat = true
+				break
+			}
+		}
+		if !isKnownFormat {
+			return nil, fmt.Errorf("unknown output format %s", of.format)
+		}
+
+		if of.path == "" {
+			if stdoutFormat != "" {
+				return nil, fmt.Errorf("output formats %s and %s are both printed to stdout: "+
+					"set a file path for one of them, e.g. %s:report.out", stdoutFormat, of.format, of.format)
+			}
+			stdoutFormat = of.format
+		}
+
+		ret = append(ret, of)
+	}
+
+	if len(ret) == 0 {
+		return nil, errors.New("no output format")
+	}
+
+	return ret, nil
+}
+
+// printReports prints issues by all printers: every printer gets a copy of issues
+func (e *Executor) printReports(ctx context.Context, outputs []outFormat, issues <-chan result.Issue) error {
+	var ps []printers.Printer
+	for _, of := range outputs {
+		w := logutils.StdOut
+		if of.path != "" {
+			f, err := os.Create(of.path)
+			if err != nil {
+				return errors.Wrapf(err, "can't create output file for format %s", of.format)
+			}
+			defer f.Close()
+			w = f
+		}
+
+		p, err := e.createPrinter(of.format, w)
+		if err != nil {
+			return err
+		}
+		ps = append(ps, p)
+	}
+
+	issueChs := make([]chan result.Issue, 0, len(ps))
+	errCh := make(chan error, len(ps))
+	for _, p := range ps {
+		p := p
+		issuesCh := make(chan result.Issue, 1024)
+		issueChs = append(issueChs, issuesCh)
+		go func() {
+			err := p.Print(ctx, issuesCh)
+			// the printer can fail without reading all issues: read them to not block other printers
+			for range issuesCh {
+			}
+			errCh <- err
+		}()
+	}
+
+	issuesN := 0
+	for i := range issues {
+		issuesN++
+		for _, ch := range issueChs {
+			ch <- i
+		}
+	}
+	for _, ch := range issueChs {
+		close(ch)
+	}
+
+	for range ps {
+		if err := <-errCh; err != nil {
+			return fmt.Errorf("can't print %d issues: %s", issuesN, err)
+		}
+	}
+
+	return nil
+}
+
+func (e *Executor) createPrinter(format string, w io.Writer) (printers.Printer, error) {
 	var p printers.Printer
-	format := e.cfg.Output.Format
 	switch format {
 	case config.OutFormatJSON:
-		p = printers.NewJSON(&e.reportData)
+		p = printers.NewJSON(&e.reportData, w)
 	case config.OutFormatColoredLineNumber, config.OutFormatLineNumber:
 		p = printers.NewText(e.cfg.Output.PrintIssuedLine,
 			format == config.OutFormatColoredLineNumber, e.cfg.Output.PrintLinterName,
-			e.log.Child("text_printer"))
+			e.log.Child("text_printer"), w)
 	case config.OutFormatTab:
-		p = printers.NewTab(e.cfg.Output.PrintLinterName, e.log.Child("tab_printer"))
+		p = printers.NewTab(e.cfg.Output.PrintLinterName, e.log.Child("tab_printer"), w)
 	case config.OutFormatCheckstyle:
-		p = printers.NewCheckstyle()
+		p = printers.NewCheckstyle(w)
 	case config.OutFormatCodeClimate:
-		p = printers.NewCodeClimate()
+		p = printers.NewCodeClimate(e.getEnabledLinterConfigs(), w)
 	case config.OutFormatJunitXML:
-		p = printers.NewJunitXML()
+		p = printers.NewJunitXML(w)
+	case config.OutFormatSARIF:
+		p = printers.NewSARIF(e.getEnabledLinterConfigs(), w)
+	case config.OutFormatGitHubActions:
+		p = printers.NewGitHubActions(w)
+	case config.OutFormatHTML:
+		p = printers.NewHTML(w)
 	default:
 		return nil, fmt.Errorf("unknown output format %s", format)
 	}
@@ -393,6 +689,23 @@ func (e *Executor) createPrinter() (printers.Printer, error) {
 	return p, nil
 }
 
+// getEnabledLinterConfigs returns not optimized (not merged into metalinters) enabled linters,
+// it must be called after runAnalysis.
+func (e *Executor) getEnabledLinterConfigs() []*linter.Config {
+	var ret []*linter.Config
+	for _, ld := range e.reportData.Linters {
+		if !ld.Enabled {
+			continue
+		}
+
+		if lc := e.DBManager.GetLinterConfig(ld.Name); lc != nil {
+			ret = append(ret, lc)
+		}
+	}
+
+	return ret
+}
+
 func (e *Executor) executeRun(_ *cobra.Command, args []string) {
 	needTrackResources := e.cfg.Run.IsVerbose || e.cfg.Run.PrintResourcesUsage
 	trackResourcesEndCh := make(chan struct{})
@@ -0,0 +1,268 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/daemon"
+	"github.com/golangci/golangci-lint/pkg/exitcodes"
+	"github.com/golangci/golangci-lint/pkg/fsutils"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/packages"
+	"github.com/golangci/golangci-lint/pkg/report"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func (e *Executor) initServe() {
+	e.serveCmd = &cobra.Command{
+		Use:   "serve",
+		Short: "Run a daemon linting by requests of `golangci-lint run --daemon`",
+		Long: "Run a daemon linting by requests of `golangci-lint run --daemon` in the same directory. " +
+			"It keeps loaded configuration and caches between requests and watches files to invalidate them.",
+		Run: e.executeServe,
+	}
+	e.rootCmd.AddCommand(e.serveCmd)
+
+	e.serveCmd.SetOutput(logutils.StdOut) // use custom output to properly color it in Windows terminals
+	e.initRunConfiguration(e.serveCmd)    // the daemon lints with options of the run command
+}
+
+func (e *Executor) executeServe(_ *cobra.Command, args []string) {
+	if len(args) != 0 {
+		e.log.Fatalf("Usage: golangci-lint serve")
+	}
+
+	if err := e.serve(); err != nil {
+		e.log.Fatalf("Serving error: %s", err)
+	}
+}
+
+// daemonRequestFlags are options of the run command applied by the client or passed in requests:
+// the daemon lints with its own options, other options of clients are ignored
+var daemonRequestFlags = map[string]bool{
+	"files":          true,
+	"stdin-filename": true,
+	"overlay":        true,
+
+	"daemon":                true,
+	"daemon-socket":         true,
+	"deadline":              true,
+	"out-format":            true,
+	"print-issued-lines":    true,
+	"print-linter-name":     true,
+	"print-welcome":         true,
+	"print-resources-usage": true,
+	"issues-exit-code":      true,
+	"config":                true, // the client reads output options from the config
+	"no-config":             true,
+}
+
+// lintDaemon lints by requests of clients: only one request is processed at a time
+type lintDaemon struct {
+	e           *Executor
+	wd          string
+	configFiles map[string]bool // the used config file and config files it extends
+	watcher     *daemon.Watcher
+	mu          sync.Mutex // serializes linting
+
+	changedFilesMu sync.Mutex
+	changedFiles   []string // files changed since the last linting
+
+	configChanged chan string // the daemon can't reload the config: it stops on its change
+}
+
+// newLintDaemon prepares the executor to lint by requests of clients
+// and starts watching changes of files in the working directory
+func (e *Executor) newLintDaemon(ctx context.Context) (*lintDaemon, error) {
+	if e.cfg.Issues.NeedFix {
+		return nil, errors.New("option --fix isn't supported in the daemon mode")
+	}
+
+	if err := e.goenv.Discover(ctx); err != nil {
+		e.log.Warnf("Failed to discover go env: %s", err)
+	}
+
+	wd, err := fsutils.Getwd()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get working directory")
+	}
+
+	d := &lintDaemon{
+		e:             e,
+		wd:            wd,
+		configFiles:   map[string]bool{},
+		configChanged: make(chan string, 1),
+	}
+	if usedConfigFile := viper.ConfigFileUsed(); usedConfigFile != "" {
+		settings, err := config.ReadFileSettings(usedConfigFile)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range settings.Files {
+			d.configFiles[f] = true
+		}
+	}
+
+	skipDirs := e.cfg.Run.SkipDirs
+	if e.cfg.Run.UseDefaultSkipDirs {
+		skipDirs = append(skipDirs, packages.StdExcludeDirRegexps...)
+	}
+	if d.watcher, err = daemon.NewWatcher(wd, skipDirs, e.log.Child("watcher"), d.fileChanged); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+func (d *lintDaemon) Close() error {
+	return d.watcher.Close()
+}
+
+func (e *Executor) serve() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d, err := e.newLintDaemon(ctx)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	socketPath := e.cfg.Run.DaemonSocket
+	if socketPath == "" {
+		socketPath = daemon.DefaultSocketPath(d.wd)
+	}
+	server, err := daemon.Listen(socketPath, d.lint, e.log.Child("server"))
+	if err != nil {
+		return err
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	stopped := make(chan error, 1)
+	go func() {
+		var stopErr error
+		select {
+		case <-signals:
+		case configFile := <-d.configChanged:
+			stopErr = fmt.Errorf("config file %s was changed: restart golangci-lint serve to reload it", configFile)
+		}
+
+		cancel()
+		if err := server.Close(); err != nil {
+			e.log.Warnf("Can't close daemon socket: %s", err)
+		}
+		stopped <- stopErr
+	}()
+
+	defer e.silenceOutput()()
+
+	e.log.Infof("Serving lint requests for %s on %s", d.wd, socketPath)
+	if err = server.Serve(ctx); err != nil {
+		return err
+	}
+	return <-stopped
+}
+
+// isConfigFile returns true for the used config file, config files it extends
+// and the config file found in the working directory, e.g. a new one
+func (d *lintDaemon) isConfigFile(path string) bool {
+	if d.configFiles[path] {
+		return true
+	}
+
+	return filepath.Dir(path) == d.wd && config.FindConfigFile(d.wd) == path
+}
+
+func (d *lintDaemon) fileChanged(path string) {
+	if d.isConfigFile(path) {
+		select {
+		case d.configChanged <- path:
+		default: // the daemon is already stopping
+		}
+		return
+	}
+
+	d.changedFilesMu.Lock()
+	d.changedFiles = append(d.changedFiles, path)
+	d.changedFilesMu.Unlock()
+}
+
+func (d *lintDaemon) lint(ctx context.Context, req *daemon.Request) *daemon.Response {
+	if req.Wd != d.wd {
+		return newDaemonErrorResponse(fmt.Errorf("daemon lints %s, not %s: run golangci-lint serve there", d.wd, req.Wd))
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.changedFilesMu.Lock()
+	changedFiles := d.changedFiles
+	d.changedFiles = nil
+	d.changedFilesMu.Unlock()
+
+	e := d.e
+	e.contextLoader.PrepareReload(changedFiles)
+	e.reportData = report.Data{}
+	if ignoredFlags := ignoredDaemonRequestFlags(req.Flags); len(ignoredFlags) != 0 {
+		e.log.Warnf("Options %s are ignored by the daemon: pass them to golangci-lint serve",
+			strings.Join(ignoredFlags, ", "))
+	}
+	e.cfg.Run.Files = req.Files
+	e.cfg.Run.StdinFilename = req.StdinFilename
+
+	ctx, cancel := context.WithTimeout(ctx, e.cfg.Run.Deadline)
+	defer cancel()
+
+	issuesCh, err := e.runAnalysis(ctx, req.Args, req.Overlay)
+	if err != nil {
+		return newDaemonErrorResponse(err)
+	}
+
+	issues := []result.Issue{}
+	for i := range issuesCh {
+		issues = append(issues, i)
+	}
+	if ctx.Err() != nil {
+		return newDaemonErrorResponse(&exitcodes.ExitError{
+			Message: "deadline exceeded: try increase it by passing --deadline option to golangci-lint serve",
+			Code:    exitcodes.Timeout,
+		})
+	}
+
+	reportData := e.reportData
+	return &daemon.Response{
+		Issues: issues,
+		Report: &reportData,
+	}
+}
+
+func ignoredDaemonRequestFlags(flags []string) []string {
+	var ret []string
+	for _, f := range flags {
+		if !daemonRequestFlags[f] {
+			ret = append(ret, "--"+f)
+		}
+	}
+	return ret
+}
+
+func newDaemonErrorResponse(err error) *daemon.Response {
+	resp := &daemon.Response{Error: err.Error()}
+	if exitErr, ok := errors.Cause(err).(*exitcodes.ExitError); ok {
+		resp.ErrorCode = exitErr.Code
+	}
+
+	return resp
+}
@@ -3,7 +3,9 @@ package config
 import (
 	"errors"
 	"fmt"
+	"os"
 	"regexp"
+	"strings"
 	"time"
 )
 
@@ -15,6 +17,9 @@ const (
 	OutFormatCheckstyle        = "checkstyle"
 	OutFormatCodeClimate       = "code-climate"
 	OutFormatJunitXML          = "junit-xml"
+	OutFormatSARIF             = "sarif"
+	OutFormatGitHubActions     = "github-actions"
+	OutFormatHTML              = "html"
 )
 
 var OutFormats = []string{
@@ -25,6 +30,9 @@ var OutFormats = []string{
 	OutFormatCheckstyle,
 	OutFormatCodeClimate,
 	OutFormatJunitXML,
+	OutFormatSARIF,
+	OutFormatGitHubActions,
+	OutFormatHTML,
 }
 
 type ExcludePattern struct {
@@ -105,13 +113,25 @@ type Run struct {
 	Concurrency         int
 	PrintResourcesUsage bool `mapstructure:"print-resources-usage"`
 
-	Config   string
-	NoConfig bool
+	Config       string
+	NoConfig     bool
+	StrictConfig bool `mapstructure:"strict-config"` // unknown keys in config files are errors
 
 	Args []string
 
-	BuildTags           []string `mapstructure:"build-tags"`
-	ModulesDownloadMode string   `mapstructure:"modules-download-mode"`
+	Files         bool   // treat Args as files to lint
+	StdinFilename string `mapstructure:"stdin-filename"`
+	Overlay       string // go build -overlay file replacing contents of files
+
+	Daemon       bool   // send lint requests to the daemon
+	DaemonSocket string `mapstructure:"daemon-socket"`
+
+	BuildTags           []string           `mapstructure:"build-tags"`
+	BuildMatrix         []BuildCombination `mapstructure:"build-matrix"`
+	ModulesDownloadMode string             `mapstructure:"modules-download-mode"`
+
+	Modules         []string // directories of modules to lint separately
+	DiscoverModules bool     `mapstructure:"discover-modules"`
 
 	ExitCodeIfIssuesFound int  `mapstructure:"issues-exit-code"`
 	AnalyzeTests          bool `mapstructure:"tests"`
@@ -123,6 +143,74 @@ type Run struct {
 	UseDefaultSkipDirs bool     `mapstructure:"skip-dirs-use-default"`
 }
 
+// FilesToLint returns files issues are reported for: the stdin file name
+// or files from command-line arguments. It's empty if all files must be linted.
+func (r *Run) FilesToLint() []string {
+	if r.StdinFilename != "" {
+		return []string{r.StdinFilename}
+	}
+	if r.Files {
+		return r.Args
+	}
+	return nil
+}
+
+// BuildTagsFor returns tags of run.build-tags and of the build combination
+func (r *Run) BuildTagsFor(b BuildCombination) []string {
+	if len(b.Tags) == 0 {
+		return r.BuildTags
+	}
+
+	tags := append([]string{}, r.BuildTags...)
+	return append(tags, b.Tags...)
+}
+
+// BuildCombination is a combination of build tags and a target platform to load packages for:
+// empty GOOS and GOARCH mean the current platform
+type BuildCombination struct {
+	Tags   []string `mapstructure:"tags"`
+	GOOS   string   `mapstructure:"goos"`
+	GOARCH string   `mapstructure:"goarch"`
+}
+
+func (b BuildCombination) Validate() error {
+	if len(b.Tags) == 0 && b.GOOS == "" && b.GOARCH == "" {
+		return errors.New("at least one of tags, goos and goarch should be set")
+	}
+	return nil
+}
+
+// Env returns the environment of go commands for the combination: nil means the current environment
+func (b BuildCombination) Env() []string {
+	if b.GOOS == "" && b.GOARCH == "" {
+		return nil
+	}
+
+	env := os.Environ()
+	if b.GOOS != "" {
+		env = append(env, "GOOS="+b.GOOS)
+	}
+	if b.GOARCH != "" {
+		env = append(env, "GOARCH="+b.GOARCH)
+	}
+	return env
+}
+
+// String returns the name of the combination recorded on issues, e.g. goos=linux,tags=integration
+func (b BuildCombination) String() string {
+	var parts []string
+	if b.GOOS != "" {
+		parts = append(parts, "goos="+b.GOOS)
+	}
+	if b.GOARCH != "" {
+		parts = append(parts, "goarch="+b.GOARCH)
+	}
+	if len(b.Tags) != 0 {
+		parts = append(parts, "tags="+strings.Join(b.Tags, " "))
+	}
+	return strings.Join(parts, ",")
+}
+
 type LintersSettings struct {
 	Govet  GovetSettings
 	Golint struct {
@@ -179,6 +267,14 @@ type LintersSettings struct {
 	Gocritic GocriticSettings
 	Godox    GodoxSettings
 	Dogsled  DogsledSettings
+
+	Nolintlint NolintlintSettings
+
+	// Timeouts limit run times of linters: linter name -> timeout
+	Timeouts map[string]time.Duration
+
+	Custom   map[string]CustomLinterSettings
+	External map[string]ExternalLinterSettings
 }
 
 type GovetSettings struct {
@@ -239,6 +335,47 @@ type DogsledSettings struct {
 	MaxBlankIdentifiers int `mapstructure:"max-blank-identifiers"`
 }
 
+type NolintlintSettings struct {
+	AllowUnused        bool `mapstructure:"allow-unused"`
+	RequireSpecific    bool `mapstructure:"require-specific"`
+	RequireExplanation bool `mapstructure:"require-explanation"`
+}
+
+// CustomLinterSettings describes a linter which isn't built into golangci-lint.
+// Exactly one of Path and VetTool must be set.
+type CustomLinterSettings struct {
+	// Path is a path to a Go plugin exporting `var Analyzers []*analysis.Analyzer`
+	Path string
+	// VetTool is a path to a vet-style analysis tool built with unitchecker.Main
+	VetTool string `mapstructure:"vettool"`
+
+	Description string
+	OriginalURL string `mapstructure:"original-url"`
+	Presets     []string
+
+	// Settings are flags of plugin analyzers: analyzer name -> flag name -> value
+	Settings map[string]map[string]interface{}
+}
+
+// ExternalLinterSettings describes a linter running an arbitrary command.
+// Its output is parsed line by line into issues.
+type ExternalLinterSettings struct {
+	// Command is a command with arguments: `{files}` is expanded into Go files
+	// and `{dir}` into a package directory
+	Command []string
+	// Mode is `files` to run the command once over all files or `package` to run it per package
+	Mode string
+
+	// Pattern is a list of regexps with named groups file, line, message and optional col and severity
+	Pattern []string
+	// ErrorFormat is a list of vim errorformats: it's an alternative to Pattern
+	ErrorFormat []string `mapstructure:"errorformat"`
+
+	Description string
+	OriginalURL string `mapstructure:"original-url"`
+	Presets     []string
+}
+
 var defaultLintersSettings = LintersSettings{
 	Lll: LllSettings{
 		LineLength: 120,
@@ -264,6 +401,11 @@ var defaultLintersSettings = LintersSettings{
 	Dogsled: DogsledSettings{
 		MaxBlankIdentifiers: 2,
 	},
+	Nolintlint: NolintlintSettings{
+		AllowUnused:        false,
+		RequireSpecific:    true,
+		RequireExplanation: true,
+	},
 }
 
 type Linters struct {
@@ -276,7 +418,7 @@ type Linters struct {
 	Presets []string
 }
 
-type ExcludeRule struct {
+type BaseRule struct {
 	Linters []string
 	Path    string
 	Text    string
@@ -291,35 +433,64 @@ func validateOptionalRegex(value string) error {
 	return err
 }
 
-func (e ExcludeRule) Validate() error {
-	if err := validateOptionalRegex(e.Path); err != nil {
+func (b BaseRule) Validate(minConditionsCount int) error {
+	if err := validateOptionalRegex(b.Path); err != nil {
 		return fmt.Errorf("invalid path regex: %v", err)
 	}
-	if err := validateOptionalRegex(e.Text); err != nil {
+	if err := validateOptionalRegex(b.Text); err != nil {
 		return fmt.Errorf("invalid text regex: %v", err)
 	}
-	if err := validateOptionalRegex(e.Source); err != nil {
+	if err := validateOptionalRegex(b.Source); err != nil {
 		return fmt.Errorf("invalid source regex: %v", err)
 	}
 	nonBlank := 0
-	if len(e.Linters) > 0 {
+	if len(b.Linters) > 0 {
 		nonBlank++
 	}
-	if e.Path != "" {
+	if b.Path != "" {
 		nonBlank++
 	}
-	if e.Text != "" {
+	if b.Text != "" {
 		nonBlank++
 	}
-	if e.Source != "" {
+	if b.Source != "" {
 		nonBlank++
 	}
-	if nonBlank < 2 {
-		return errors.New("at least 2 of (text, source, path, linters) should be set")
+	if nonBlank < minConditionsCount {
+		return fmt.Errorf("at least %d of (text, source, path, linters) should be set", minConditionsCount)
 	}
 	return nil
 }
 
+const excludeRuleMinConditionsCount = 2
+
+type ExcludeRule struct {
+	BaseRule `mapstructure:",squash"`
+}
+
+func (e ExcludeRule) Validate() error {
+	return e.BaseRule.Validate(excludeRuleMinConditionsCount)
+}
+
+const severityRuleMinConditionsCount = 1
+
+type SeverityRule struct {
+	BaseRule `mapstructure:",squash"`
+	Severity string
+}
+
+func (s SeverityRule) Validate() error {
+	if s.Severity == "" {
+		return errors.New("severity should be set")
+	}
+	return s.BaseRule.Validate(severityRuleMinConditionsCount)
+}
+
+type Severity struct {
+	Default string         `mapstructure:"default-severity"`
+	Rules   []SeverityRule `mapstructure:"rules"`
+}
+
 type Issues struct {
 	ExcludePatterns    []string      `mapstructure:"exclude"`
 	ExcludeRules       []ExcludeRule `mapstructure:"exclude-rules"`
@@ -332,10 +503,17 @@ type Issues struct {
 	DiffPatchFilePath string `mapstructure:"new-from-patch"`
 	Diff              bool   `mapstructure:"new"`
 
+	BaselinePath      string `mapstructure:"baseline"`
+	WriteBaselinePath string `mapstructure:"write-baseline"`
+
 	NeedFix bool `mapstructure:"fix"`
+	FixDiff bool `mapstructure:"fix-diff"`
 }
 
 type Config struct { //nolint:maligned
+	// Extends lists config files merged before the config file: see ReadFileSettings
+	Extends []string
+
 	Run Run
 
 	Output struct {
@@ -349,6 +527,7 @@ type Config struct { //nolint:maligned
 	LintersSettings LintersSettings `mapstructure:"linters-settings"`
 	Linters         Linters
 	Issues          Issues
+	Severity        Severity
 
 	InternalTest bool // Option is used only for testing golangci-lint code, don't use it
 }
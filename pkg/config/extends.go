@@ -0,0 +1,415 @@
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"go/build"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	homedir "github.com/mitchellh/go-homedir"
+	"github.com/spf13/viper"
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/golangci/golangci-lint/pkg/fsutils"
+)
+
+// FileSettings are settings of a config file merged with settings of config files it extends:
+// extended files are merged in order and the extending file is merged last. Maps are merged deeply,
+// slices are appended, scalars are overridden. Linters of linters.disable are removed
+// from linters.enable of extended files and vice versa.
+type FileSettings struct {
+	Settings map[string]interface{}
+	Files    []string // config files the settings are read from: extended files go first
+
+	origins     map[string]string   // key path of a scalar -> file it comes from
+	elemOrigins map[string][]string // key path of a slice -> files its elements come from
+}
+
+func newFileSettings() *FileSettings {
+	return &FileSettings{
+		Settings:    map[string]interface{}{},
+		origins:     map[string]string{},
+		elemOrigins: map[string][]string{},
+	}
+}
+
+// ReadFileSettings reads settings of the config file and of config files it extends
+func ReadFileSettings(configFile string) (*FileSettings, error) {
+	return readFileSettings(configFile, map[string]bool{})
+}
+
+func readFileSettings(configFile string, loading map[string]bool) (*FileSettings, error) {
+	configFile, err := filepath.Abs(configFile)
+	if err != nil {
+		return nil, err
+	}
+	if loading[configFile] {
+		return nil, fmt.Errorf("config file %s extends itself", configFile)
+	}
+	loading[configFile] = true
+	defer delete(loading, configFile)
+
+	v := viper.New()
+	v.SetConfigFile(configFile)
+	if err = v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("can't read config file %s: %s", configFile, err)
+	}
+
+	settings := joinDottedSettingsKeys(v.AllSettings(), reflect.TypeOf(Config{})).(map[string]interface{})
+	extends, err := extendsList(settings["extends"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid extends in %s: %s", configFile, err)
+	}
+	delete(settings, "extends")
+
+	ret := newFileSettings()
+	for _, ref := range extends {
+		extendedFile, err := resolveExtends(ref, filepath.Dir(configFile))
+		if err != nil {
+			return nil, fmt.Errorf("can't resolve %q extended by %s: %s", ref, configFile, err)
+		}
+
+		extended, err := readFileSettings(extendedFile, loading)
+		if err != nil {
+			return nil, err
+		}
+		ret.mergeMap("", ret.Settings, extended.Settings, extended.originOf)
+		ret.Files = append(ret.Files, extended.Files...)
+	}
+
+	ret.mergeMap("", ret.Settings, settings, func(string, int) string { return configFile })
+	ret.Files = append(ret.Files, configFile)
+	return ret, nil
+}
+
+func extendsList(v interface{}) ([]string, error) {
+	switch v := v.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []string{v}, nil
+	case []interface{}:
+		var ret []string
+		for _, e := range v {
+			s, ok := e.(string)
+			if !ok {
+				return nil, fmt.Errorf("%v isn't a path", e)
+			}
+			ret = append(ret, s)
+		}
+		return ret, nil
+	default:
+		return nil, fmt.Errorf("%v isn't a list of paths", v)
+	}
+}
+
+// originOf returns the file the value by the key path comes from: elemIndex is an index
+// of a slice element or -1 for other values
+func (s *FileSettings) originOf(path string, elemIndex int) string {
+	if elemIndex >= 0 {
+		if origins := s.elemOrigins[path]; elemIndex < len(origins) {
+			return origins[elemIndex]
+		}
+		return ""
+	}
+	return s.origins[path]
+}
+
+func joinKeyPath(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
+func (s *FileSettings) mergeMap(prefix string, dst, src map[string]interface{},
+	originOf func(path string, elemIndex int) string) {
+	if prefix == "linters" {
+		// a linter enabled by one config and disabled by a following one is disabled and vice versa
+		s.removeElems("linters.enable", dst, "enable", src["disable"])
+		s.removeElems("linters.disable", dst, "disable", src["enable"])
+	}
+
+	for key, srcValue := range src {
+		path := joinKeyPath(prefix, key)
+		switch srcValue := srcValue.(type) {
+		case map[string]interface{}:
+			dstMap, ok := dst[key].(map[string]interface{})
+			if !ok {
+				dstMap = map[string]interface{}{}
+				dst[key] = dstMap
+			}
+			s.mergeMap(path, dstMap, srcValue, originOf)
+		case []interface{}:
+			dstSlice, _ := dst[key].([]interface{})
+			if dstSlice == nil {
+				s.elemOrigins[path] = nil
+			}
+			for i, elem := range srcValue {
+				dstSlice = append(dstSlice, elem)
+				s.elemOrigins[path] = append(s.elemOrigins[path], originOf(path, i))
+			}
+			if dstSlice == nil {
+				dstSlice = []interface{}{}
+			}
+			dst[key] = dstSlice
+		default:
+			dst[key] = srcValue
+			s.origins[path] = originOf(path, -1)
+		}
+	}
+}
+
+// removeElems removes elements of the slice toRemove from the slice dst[key] with the key path
+func (s *FileSettings) removeElems(path string, dst map[string]interface{}, key string, toRemove interface{}) {
+	removeList, _ := toRemove.([]interface{})
+	dstSlice, _ := dst[key].([]interface{})
+	if len(removeList) == 0 || len(dstSlice) == 0 {
+		return
+	}
+
+	isRemoved := map[string]bool{}
+	for _, elem := range removeList {
+		isRemoved[strings.ToLower(fmt.Sprint(elem))] = true
+	}
+
+	var elems []interface{}
+	var origins []string
+	for i, elem := range dstSlice {
+		if !isRemoved[strings.ToLower(fmt.Sprint(elem))] {
+			elems = append(elems, elem)
+			origins = append(origins, s.originOf(path, i))
+		}
+	}
+	dst[key] = elems
+	s.elemOrigins[path] = origins
+}
+
+// resolveExtends returns the path of the config file referenced by extends of the config in the directory dir.
+// Local paths are relative to the directory. Other paths are paths of files inside Go modules in the module cache:
+// module@version/path or module/path of the module required by go.mod of the module containing the directory.
+func resolveExtends(ref, dir string) (string, error) {
+	ref, err := homedir.Expand(ref)
+	if err != nil {
+		return "", err
+	}
+
+	localPath := ref
+	if !filepath.IsAbs(localPath) {
+		localPath = filepath.Join(dir, localPath)
+	}
+	if filepath.IsAbs(ref) || strings.HasPrefix(ref, ".") || isFile(localPath) {
+		return localPath, nil
+	}
+
+	ref = filepath.ToSlash(ref)
+	if i := strings.Index(ref, "@"); i != -1 {
+		versionAndFile := strings.SplitN(ref[i+1:], "/", 2)
+		if len(versionAndFile) != 2 {
+			return "", fmt.Errorf("no file path after the module version")
+		}
+		return moduleCacheFile(ref[:i], versionAndFile[0], versionAndFile[1])
+	}
+
+	requires, err := readGoModRequires(dir)
+	if err != nil {
+		return "", err
+	}
+
+	parts := strings.Split(ref, "/")
+	for i := len(parts) - 1; i > 0; i-- {
+		modulePath := strings.Join(parts[:i], "/")
+		if version, ok := requires[modulePath]; ok {
+			return moduleCacheFile(modulePath, version, strings.Join(parts[i:], "/"))
+		}
+	}
+
+	return "", fmt.Errorf("it's neither a local file nor a file of a module required by go.mod")
+}
+
+func isFile(path string) bool {
+	fi, err := os.Stat(path)
+	return err == nil && fi.Mode().IsRegular()
+}
+
+func moduleCacheDir() string {
+	if dir := os.Getenv("GOMODCACHE"); dir != "" {
+		return dir
+	}
+
+	gopath := build.Default.GOPATH
+	if paths := filepath.SplitList(gopath); len(paths) != 0 {
+		gopath = paths[0]
+	}
+	return filepath.Join(gopath, "pkg", "mod")
+}
+
+// escapeModulePath escapes the module path or version as the module cache does: upper-case letters are
+// replaced by ! and lower-case letters
+func escapeModulePath(path string) string {
+	var b strings.Builder
+	for _, r := range path {
+		if r >= 'A' && r <= 'Z' {
+			b.WriteByte('!')
+			r += 'a' - 'A'
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func moduleCacheFile(modulePath, version, file string) (string, error) {
+	moduleDir := filepath.Join(moduleCacheDir(), filepath.FromSlash(escapeModulePath(modulePath))+"@"+escapeModulePath(version))
+	path := filepath.Join(moduleDir, filepath.FromSlash(file))
+	if !isFile(path) {
+		return "", fmt.Errorf("no file %s in the module cache: run go mod download %s@%s", path, modulePath, version)
+	}
+	return path, nil
+}
+
+// readGoModRequires returns versions of modules required by go.mod of the module containing the directory
+func readGoModRequires(dir string) (map[string]string, error) {
+	goMod := ""
+	for {
+		if path := filepath.Join(dir, "go.mod"); isFile(path) {
+			goMod = path
+			break
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, fmt.Errorf("no go.mod to find the module version in")
+		}
+		dir = parent
+	}
+
+	f, err := os.Open(goMod)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	requires := map[string]string{}
+	inRequireBlock := false
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "//"); i != -1 {
+			line = line[:i]
+		}
+		line = strings.TrimSpace(line)
+
+		var fields []string
+		switch {
+		case line == "require (":
+			inRequireBlock = true
+			continue
+		case inRequireBlock && line == ")":
+			inRequireBlock = false
+			continue
+		case inRequireBlock:
+			fields = strings.Fields(line)
+		case strings.HasPrefix(line, "require "):
+			fields = strings.Fields(strings.TrimPrefix(line, "require "))
+		}
+
+		if len(fields) >= 2 {
+			requires[strings.Trim(fields[0], `"`)] = fields[1]
+		}
+	}
+
+	return requires, scanner.Err()
+}
+
+// Write writes settings in YAML with origins of values in comments
+func (s *FileSettings) Write(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	s.writeMap(bw, "", s.Settings, 0)
+	return bw.Flush()
+}
+
+func (s *FileSettings) originComment(path string, elemIndex int) string {
+	origin := s.originOf(path, elemIndex)
+	if origin == "" {
+		return ""
+	}
+
+	if relPath, err := fsutils.ShortestRelPath(origin, ""); err == nil {
+		origin = relPath
+	}
+	return " # " + origin
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func stringMap(v interface{}) (map[string]interface{}, bool) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		return v, true
+	case map[interface{}]interface{}:
+		ret := map[string]interface{}{}
+		for k, elem := range v {
+			ret[fmt.Sprint(k)] = elem
+		}
+		return ret, true
+	default:
+		return nil, false
+	}
+}
+
+func yamlScalar(v interface{}) string {
+	out, err := yaml.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return strings.TrimSuffix(string(out), "\n")
+}
+
+func (s *FileSettings) writeMap(w io.Writer, prefix string, m map[string]interface{}, indent int) {
+	pad := strings.Repeat("  ", indent)
+	for _, key := range sortedKeys(m) {
+		path := joinKeyPath(prefix, key)
+		value := m[key]
+
+		if valueMap, ok := stringMap(value); ok {
+			if len(valueMap) == 0 {
+				fmt.Fprintf(w, "%s%s: {}\n", pad, key)
+				continue
+			}
+			fmt.Fprintf(w, "%s%s:\n", pad, key)
+			s.writeMap(w, path, valueMap, indent+1)
+			continue
+		}
+
+		if slice, ok := value.([]interface{}); ok {
+			if len(slice) == 0 {
+				fmt.Fprintf(w, "%s%s: []\n", pad, key)
+				continue
+			}
+			fmt.Fprintf(w, "%s%s:\n", pad, key)
+			for i, elem := range slice {
+				if elemMap, ok := stringMap(elem); ok {
+					fmt.Fprintf(w, "%s  -%s\n", pad, s.originComment(path, i))
+					s.writeMap(w, "", elemMap, indent+2)
+					continue
+				}
+				fmt.Fprintf(w, "%s  - %s%s\n", pad, yamlScalar(elem), s.originComment(path, i))
+			}
+			continue
+		}
+
+		fmt.Fprintf(w, "%s%s: %s%s\n", pad, key, yamlScalar(value), s.originComment(path, -1))
+	}
+}
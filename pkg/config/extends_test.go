@@ -0,0 +1,91 @@
+package config
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+		require.NoError(t, ioutil.WriteFile(path, []byte(content), os.ModePerm))
+	}
+}
+
+func TestReadFileSettings(t *testing.T) {
+	dir, err := ioutil.TempDir("", "golangci_lint_extends_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	modCache := filepath.Join(dir, "modcache")
+	defer os.Setenv("GOMODCACHE", os.Getenv("GOMODCACHE"))
+	require.NoError(t, os.Setenv("GOMODCACHE", modCache))
+
+	writeTestFiles(t, dir, map[string]string{
+		"modcache/github.com/!org/lint@v1.2.0/base.yml": `
+run:
+  deadline: 1m
+  skip-dirs: [gen]
+linters:
+  enable: [godox, misspell]
+  disable: [errcheck]
+`,
+		"project/go.mod": "module example.com/project\n\nrequire (\n\tgithub.com/Org/lint v1.2.0 // indirect\n)\n",
+		"project/strict.yml": `
+extends: github.com/Org/lint/base.yml
+run:
+  deadline: 2m
+linters:
+  enable: [errcheck]
+`,
+		"project/.golangci.yml": `
+extends:
+  - strict.yml
+run:
+  skip-dirs: [mocks]
+linters:
+  disable: [misspell]
+`,
+		"cycle/a.yml": "extends: [b.yml]\n",
+		"cycle/b.yml": "extends: [./a.yml]\n",
+	})
+
+	settings, err := ReadFileSettings(filepath.Join(dir, "project", ".golangci.yml"))
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{
+		"run": map[string]interface{}{
+			"deadline":  "2m",
+			"skip-dirs": []interface{}{"gen", "mocks"},
+		},
+		"linters": map[string]interface{}{
+			"enable":  []interface{}{"godox", "errcheck"},
+			"disable": []interface{}{"misspell"},
+		},
+	}, settings.Settings)
+
+	base := filepath.Join(modCache, "github.com", "!org", "lint@v1.2.0", "base.yml")
+	assert.Equal(t, filepath.Join(dir, "project", "strict.yml"), settings.originOf("run.deadline", -1))
+	assert.Equal(t, []string{base, filepath.Join(dir, "project", ".golangci.yml")}, settings.elemOrigins["run.skip-dirs"])
+	assert.Equal(t, []string{base, filepath.Join(dir, "project", "strict.yml")}, settings.elemOrigins["linters.enable"])
+
+	var out bytes.Buffer
+	require.NoError(t, settings.Write(&out))
+	assert.Contains(t, out.String(), "  deadline: 2m # ")
+	assert.Contains(t, out.String(), "    - mocks # ")
+
+	_, err = ReadFileSettings(filepath.Join(dir, "cycle", "a.yml"))
+	assert.Error(t, err)
+
+	writeTestFiles(t, dir, map[string]string{"project/missing.yml": "extends: github.com/Org/lint@v1.3.0/base.yml\n"})
+	_, err = ReadFileSettings(filepath.Join(dir, "project", "missing.yml"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "go mod download github.com/Org/lint@v1.3.0")
+}
@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 
+	"github.com/mitchellh/mapstructure"
 	"github.com/spf13/viper"
 
 	"github.com/golangci/golangci-lint/pkg/fsutils"
@@ -49,11 +51,31 @@ func (r *FileReader) Read() error {
 		r.setupConfigFileSearch()
 	}
 
-	return r.parseConfig()
+	return r.parseConfig(viper.GetViper())
 }
 
-func (r *FileReader) parseConfig() error {
-	if err := viper.ReadInConfig(); err != nil {
+// ReadFile reads the config file, e.g. the config file of a module: unlike Read
+// it doesn't search for the config file and doesn't change the used config file.
+func (r *FileReader) ReadFile(configFile string) error {
+	v := viper.New()
+	v.SetConfigFile(configFile)
+	return r.parseConfig(v)
+}
+
+// FindConfigFile returns the path of the config file in the directory or an empty string if it has no config file
+func FindConfigFile(dir string) string {
+	for _, ext := range viper.SupportedExts {
+		configFile := filepath.Join(dir, ".golangci."+ext)
+		if isFile(configFile) {
+			return configFile
+		}
+	}
+
+	return ""
+}
+
+func (r *FileReader) parseConfig(v *viper.Viper) error {
+	if err := v.ReadInConfig(); err != nil {
 		if _, ok := err.(viper.ConfigFileNotFoundError); ok {
 			return nil
 		}
@@ -61,7 +83,7 @@ func (r *FileReader) parseConfig() error {
 		return fmt.Errorf("can't read viper config: %s", err)
 	}
 
-	usedConfigFile := viper.ConfigFileUsed()
+	usedConfigFile := v.ConfigFileUsed()
 	if usedConfigFile == "" {
 		return nil
 	}
@@ -72,10 +94,105 @@ func (r *FileReader) parseConfig() error {
 	}
 	r.log.Infof("Used config file %s", usedConfigFile)
 
-	if err := viper.Unmarshal(r.cfg); err != nil {
+	configFiles := []string{v.ConfigFileUsed()}
+	if v.IsSet("extends") {
+		settings, err := ReadFileSettings(v.ConfigFileUsed())
+		if err != nil {
+			return err
+		}
+		configFiles = settings.Files
+
+		// the merged settings are unmarshaled by a separate viper: the used one
+		// keeps the config file path for config path and config print
+		merged := viper.New()
+		if err := merged.MergeConfigMap(settings.Settings); err != nil {
+			return fmt.Errorf("can't merge extended configs: %s", err)
+		}
+		r.log.Infof("Used config file %s extends %v", usedConfigFile, v.Get("extends"))
+		v = merged
+	}
+
+	return r.decodeConfig(v, configFiles)
+}
+
+// ReadNestedFiles reads the root config file overridden by config files in its subdirectories: issues
+// and linters-settings of nested config files are merged into the root config from the outer to the inner one.
+// An empty root config file means there is no root config.
+func (r *FileReader) ReadNestedFiles(rootConfigFile string, nestedConfigFiles []string) error {
+	settings := newFileSettings()
+	if rootConfigFile != "" {
+		rootSettings, err := ReadFileSettings(rootConfigFile)
+		if err != nil {
+			return err
+		}
+		settings = rootSettings
+	}
+
+	for _, configFile := range nestedConfigFiles {
+		nested, err := ReadFileSettings(configFile)
+		if err != nil {
+			return err
+		}
+		if err = r.checkUnknownKeys(nested.Settings, nested.Files); err != nil {
+			return err
+		}
+
+		for key := range nested.Settings {
+			if !nestedConfigKeys[key] {
+				r.log.Warnf("Option %s of nested config file %s isn't used: only issues and linters-settings are",
+					key, configFile)
+				delete(nested.Settings, key)
+			}
+		}
+		settings.mergeMap("", settings.Settings, nested.Settings, nested.originOf)
+	}
+
+	v := viper.New()
+	if err := v.MergeConfigMap(settings.Settings); err != nil {
+		return fmt.Errorf("can't merge nested configs: %s", err)
+	}
+	return r.decodeConfig(v, nil) // unknown keys are checked in every file
+}
+
+// nestedConfigKeys are keys of nested config files overriding the root config
+var nestedConfigKeys = map[string]bool{
+	"issues":           true,
+	"linters-settings": true,
+}
+
+// NestedConfigFiles returns config files applied to packages of the directory in addition to the root config
+// in the root directory: config files of the directory and its parents below the root directory from the outer one
+func NestedConfigFiles(rootDir, dir string) []string {
+	if !strings.HasPrefix(dir, rootDir+string(filepath.Separator)) {
+		return nil
+	}
+
+	var ret []string
+	for ; dir != rootDir; dir = filepath.Dir(dir) {
+		if configFile := FindConfigFile(dir); configFile != "" {
+			ret = append([]string{configFile}, ret...)
+		}
+	}
+	return ret
+}
+
+// decodeConfig decodes settings into the config and checks unknown keys of config files the settings are read from
+func (r *FileReader) decodeConfig(v *viper.Viper, configFiles []string) error {
+	decodeHook := viper.DecodeHook(mapstructure.ComposeDecodeHookFunc(
+		mapstructure.StringToTimeDurationHookFunc(),
+		mapstructure.StringToSliceHookFunc(","),
+		joinDottedKeysHook,
+	))
+	if err := v.Unmarshal(r.cfg, decodeHook); err != nil {
 		return fmt.Errorf("can't unmarshal config by viper: %s", err)
 	}
 
+	if len(configFiles) != 0 {
+		if err := r.checkUnknownKeys(v.AllSettings(), configFiles); err != nil {
+			return err
+		}
+	}
+
 	if err := r.validateConfig(); err != nil {
 		return fmt.Errorf("can't validate config: %s", err)
 	}
@@ -88,12 +205,128 @@ func (r *FileReader) parseConfig() error {
 	return nil
 }
 
+// joinDottedKeysHook restores keys with dots of maps of values, e.g. package paths of
+// depguard.packages-with-error-message: viper splits such keys into nested maps
+func joinDottedKeysHook(from, to reflect.Type, data interface{}) (interface{}, error) {
+	m, ok := data.(map[string]interface{})
+	if !ok || !isValuesMap(to) {
+		return data, nil
+	}
+	ret := map[string]interface{}{}
+	joinDottedKeys("", m, ret)
+	return ret, nil
+}
+
+// joinDottedSettingsKeys restores keys with dots of maps of values in settings read by viper,
+// e.g. for config print: the value is decoded into the type t
+func joinDottedSettingsKeys(value interface{}, t reflect.Type) interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	m, ok := stringMap(value)
+	if !ok {
+		return value
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		known := structKeys(t)
+		for k, v := range m {
+			if ft, ok := known[strings.ToLower(k)]; ok {
+				m[k] = joinDottedSettingsKeys(v, ft)
+			}
+		}
+	case reflect.Map:
+		if isValuesMap(t) {
+			ret := map[string]interface{}{}
+			joinDottedKeys("", m, ret)
+			return ret
+		}
+		for k, v := range m {
+			m[k] = joinDottedSettingsKeys(v, t.Elem())
+		}
+	default:
+		return value
+	}
+	return m
+}
+
+// isValuesMap checks whether the type is a map with string keys and values not decoded from maps
+func isValuesMap(t reflect.Type) bool {
+	if t.Kind() != reflect.Map || t.Key().Kind() != reflect.String {
+		return false
+	}
+	switch t.Elem().Kind() {
+	case reflect.Map, reflect.Struct, reflect.Interface, reflect.Ptr:
+		return false
+	}
+	return true
+}
+
+func joinDottedKeys(prefix string, m, ret map[string]interface{}) {
+	for k, v := range m {
+		if prefix != "" {
+			k = prefix + "." + k
+		}
+		if nested, ok := v.(map[string]interface{}); ok {
+			joinDottedKeys(k, nested, ret)
+			continue
+		}
+		ret[k] = v
+	}
+}
+
+// checkUnknownKeys reports keys of config files not matching any option: they are errors
+// with --strict-config and warnings otherwise
+func (r *FileReader) checkUnknownKeys(settings map[string]interface{}, configFiles []string) error {
+	keys := FindUnknownKeys(settings, configFiles)
+	if len(keys) == 0 {
+		return nil
+	}
+
+	for i := range keys {
+		if relPath, err := fsutils.ShortestRelPath(keys[i].File, ""); err == nil {
+			keys[i].File = relPath
+		}
+	}
+
+	isStrict := r.cfg.Run.StrictConfig || r.commandLineCfg != nil && r.commandLineCfg.Run.StrictConfig
+	if !isStrict {
+		for _, k := range keys {
+			r.log.Warnf("%s", k)
+		}
+		return nil
+	}
+
+	var lines []string
+	for _, k := range keys {
+		lines = append(lines, k.String())
+	}
+	return fmt.Errorf("unknown keys in config: %s", strings.Join(lines, "; "))
+}
+
 func (r *FileReader) validateConfig() error {
 	c := r.cfg
 	if len(c.Run.Args) != 0 {
 		return errors.New("option run.args in config isn't supported now")
 	}
 
+	if c.Run.Files {
+		return errors.New("option run.files in config isn't supported: only on command-line")
+	}
+
+	if c.Run.StdinFilename != "" {
+		return errors.New("option run.stdin-filename in config isn't supported: only on command-line")
+	}
+
+	if c.Run.Daemon {
+		return errors.New("option run.daemon in config isn't supported: only on command-line")
+	}
+
+	if c.Run.Overlay != "" {
+		return errors.New("option run.overlay in config isn't supported: only on command-line")
+	}
+
 	if c.Run.CPUProfilePath != "" {
 		return errors.New("option run.cpuprofilepath in config isn't allowed")
 	}
@@ -105,11 +338,21 @@ func (r *FileReader) validateConfig() error {
 	if c.Run.IsVerbose {
 		return errors.New("can't set run.verbose option with config: only on command-line")
 	}
+	for i, b := range c.Run.BuildMatrix {
+		if err := b.Validate(); err != nil {
+			return fmt.Errorf("error in build matrix combination #%d: %v", i, err)
+		}
+	}
 	for i, rule := range c.Issues.ExcludeRules {
 		if err := rule.Validate(); err != nil {
 			return fmt.Errorf("error in exclude rule #%d: %v", i, err)
 		}
 	}
+	for i, rule := range c.Severity.Rules {
+		if err := rule.Validate(); err != nil {
+			return fmt.Errorf("error in severity rule #%d: %v", i, err)
+		}
+	}
 	if err := c.LintersSettings.Govet.Validate(); err != nil {
 		return fmt.Errorf("error in govet config: %v", err)
 	}
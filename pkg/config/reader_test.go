@@ -0,0 +1,92 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/golangci/golangci-lint/pkg/logutils"
+)
+
+func TestReadNestedFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "golangci_lint_nested_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	writeTestFiles(t, dir, map[string]string{
+		".golangci.yml": `
+linters-settings:
+  lll:
+    line-length: 100
+issues:
+  exclude:
+    - root
+`,
+		"a/.golangci.yml": `
+linters:
+  enable-all: true
+issues:
+  exclude:
+    - a
+`,
+		"a/b/c/.golangci.yml": `
+linters-settings:
+  lll:
+    line-length: 140
+`,
+		"d/d.go": "package d\n",
+	})
+
+	rootConfigFile := filepath.Join(dir, ".golangci.yml")
+	nested := NestedConfigFiles(dir, filepath.Join(dir, "a", "b", "c", "e"))
+	assert.Equal(t, []string{filepath.Join(dir, "a", ".golangci.yml"), filepath.Join(dir, "a", "b", "c", ".golangci.yml")},
+		nested)
+	assert.Empty(t, NestedConfigFiles(dir, filepath.Join(dir, "d")))
+	assert.Empty(t, NestedConfigFiles(dir, dir))
+	assert.Empty(t, NestedConfigFiles(filepath.Join(dir, "a", "b"), filepath.Join(dir, "a")))
+
+	cfg := NewDefault()
+	r := NewFileReader(cfg, nil, logutils.NewStderrLog("config_reader"))
+	require.NoError(t, r.ReadNestedFiles(rootConfigFile, nested))
+	assert.Equal(t, 140, cfg.LintersSettings.Lll.LineLength)
+	assert.Equal(t, []string{"root", "a"}, cfg.Issues.ExcludePatterns)
+	assert.False(t, cfg.Linters.EnableAll, "only issues and linters-settings are overridden")
+}
+
+func TestReadFileDottedKeys(t *testing.T) {
+	dir, err := ioutil.TempDir("", "golangci_lint_dotted_keys_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	writeTestFiles(t, dir, map[string]string{
+		".golangci.yml": `
+run:
+  strict-config: true
+linters-settings:
+  depguard:
+    packages-with-error-message:
+      github.com/sirupsen/logrus: "logging is allowed only by logutils.Log"
+      errors: "use github.com/pkg/errors"
+  lll:
+    line-length: 100
+`,
+	})
+
+	cfg := NewDefault()
+	r := NewFileReader(cfg, nil, logutils.NewStderrLog("config_reader"))
+	require.NoError(t, r.ReadFile(filepath.Join(dir, ".golangci.yml")))
+	assert.Equal(t, map[string]string{
+		"github.com/sirupsen/logrus": "logging is allowed only by logutils.Log",
+		"errors":                     "use github.com/pkg/errors",
+	}, cfg.LintersSettings.Depguard.PackagesWithErrorMessage)
+	assert.Equal(t, 100, cfg.LintersSettings.Lll.LineLength)
+
+	settings, err := ReadFileSettings(filepath.Join(dir, ".golangci.yml"))
+	require.NoError(t, err)
+	depguard := settings.Settings["linters-settings"].(map[string]interface{})["depguard"].(map[string]interface{})
+	assert.Contains(t, depguard["packages-with-error-message"], "github.com/sirupsen/logrus")
+}
@@ -0,0 +1,221 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SchemaEnums are allowed values of options by key paths, e.g. linters.enable: elements of slices
+// are checked for slice options, keys are checked for map options. Elements of slices of structs
+// have the key path of the slice, e.g. issues.exclude-rules.linters.
+type SchemaEnums map[string][]string
+
+// schemaEnums are enums of the schema: values of open enums are suggested, but other values are allowed too,
+// e.g. names of custom linters in addition to names of built-in linters
+type schemaEnums struct {
+	closed SchemaEnums
+	open   SchemaEnums
+}
+
+// schema returns the schema of values of the option by the key path or nil if it has no enum
+func (e schemaEnums) schema(path string) map[string]interface{} {
+	if enum := e.open[path]; len(enum) != 0 {
+		return map[string]interface{}{
+			"anyOf": []interface{}{
+				map[string]interface{}{"enum": enum},
+				map[string]interface{}{"type": "string"},
+			},
+		}
+	}
+	if enum := e.closed[path]; len(enum) != 0 {
+		return map[string]interface{}{"enum": enum}
+	}
+	return nil
+}
+
+// commandLineOnlyKeys are options rejected in config files by validateConfig
+// and options making no sense in config files
+var commandLineOnlyKeys = map[string]bool{
+	"internaltest":       true,
+	"run.args":           true,
+	"run.config":         true,
+	"run.noconfig":       true,
+	"run.files":          true,
+	"run.stdin-filename": true,
+	"run.daemon":         true,
+	"run.overlay":        true,
+	"run.cpuprofilepath": true,
+	"run.memprofilepath": true,
+	"run.verbose":        true,
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// builtinSchemaEnums returns enums of options with values known by the config package
+func builtinSchemaEnums() SchemaEnums {
+	var checks, tags []string
+	for _, checker := range allGocriticCheckers {
+		checks = append(checks, checker.Name)
+	}
+	for tag := range buildGocriticTagToCheckersMap() {
+		tags = append(tags, tag)
+	}
+	sort.Strings(checks)
+	sort.Strings(tags)
+
+	return SchemaEnums{
+		"run.modules-download-mode":                 {"readonly", "release", "vendor"},
+		"output.color":                              {"always", "auto", "never"},
+		"linters-settings.depguard.list-type":       {"blacklist", "whitelist"},
+		"linters-settings.gocritic.enabled-checks":  checks,
+		"linters-settings.gocritic.disabled-checks": checks,
+		"linters-settings.gocritic.settings":        checks,
+		"linters-settings.gocritic.enabled-tags":    tags,
+	}
+}
+
+// JSONSchema returns the JSON Schema of config files: defaults of options are their
+// values in defaults, allowed values are from enums and values known by the config package.
+// Values of openEnums are only suggested: other values are allowed too.
+func JSONSchema(defaults *Config, enums, openEnums SchemaEnums) map[string]interface{} {
+	allEnums := schemaEnums{closed: builtinSchemaEnums(), open: openEnums}
+	for k, v := range enums {
+		allEnums.closed[k] = v
+	}
+
+	schema := schemaOf(reflect.TypeOf(*defaults), reflect.ValueOf(*defaults), "", allEnums)
+	props := schema["properties"].(map[string]interface{})
+	for k := range ignoredKeys {
+		props[k] = map[string]interface{}{"type": "object", "description": "Settings of other tools"}
+	}
+	props["output"].(map[string]interface{})["properties"].(map[string]interface{})["format"] =
+		outputFormatSchema(defaults.Output.Format)
+
+	schema["$schema"] = "http://json-schema.org/draft-07/schema#"
+	schema["title"] = "golangci-lint config"
+	return schema
+}
+
+// outputFormatSchema returns the schema of output.format: it's one of output formats or
+// a comma-separated list of formats with optional output file paths
+func outputFormatSchema(defaultFormat string) map[string]interface{} {
+	format := fmt.Sprintf("(%s)(:[^,]+)?", strings.Join(OutFormats, "|"))
+	ret := map[string]interface{}{
+		"type": "string",
+		"anyOf": []interface{}{
+			map[string]interface{}{"enum": OutFormats},
+			map[string]interface{}{"pattern": fmt.Sprintf("^%s(,%s)*$", format, format)},
+		},
+	}
+	if defaultFormat != "" {
+		ret["default"] = defaultFormat
+	}
+	return ret
+}
+
+// schemaOf returns the schema of values of the type: v is the default value or an invalid value if there is no default
+func schemaOf(t reflect.Type, v reflect.Value, path string, enums schemaEnums) map[string]interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+		if v.IsValid() {
+			v = v.Elem()
+		}
+	}
+
+	ret := map[string]interface{}{}
+	if v.IsValid() && !isZero(v) {
+		if t == durationType {
+			ret["default"] = time.Duration(v.Int()).String()
+		} else if t.Kind() != reflect.Struct {
+			ret["default"] = v.Interface()
+		}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		ret["type"] = "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if t == durationType {
+			ret["type"] = "string" // e.g. 1m30s
+		} else {
+			ret["type"] = "integer"
+		}
+	case reflect.Float32, reflect.Float64:
+		ret["type"] = "number"
+	case reflect.String:
+		ret["type"] = "string"
+		for k, s := range enums.schema(path) {
+			ret[k] = s
+		}
+	case reflect.Slice, reflect.Array:
+		ret["type"] = "array"
+		items := schemaOf(t.Elem(), reflect.Value{}, path, enums)
+		if len(items) != 0 {
+			ret["items"] = items
+		}
+	case reflect.Map:
+		ret["type"] = "object"
+		if names := enums.schema(path); names != nil {
+			ret["propertyNames"] = names
+		}
+		if values := schemaOf(t.Elem(), reflect.Value{}, path+".*", enums); len(values) != 0 {
+			ret["additionalProperties"] = values
+		}
+	case reflect.Struct:
+		ret["type"] = "object"
+		ret["properties"] = structPropertiesSchema(t, v, path, enums)
+		ret["additionalProperties"] = false
+	}
+
+	return ret
+}
+
+func structPropertiesSchema(t reflect.Type, v reflect.Value, path string, enums schemaEnums) map[string]interface{} {
+	props := map[string]interface{}{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		var fv reflect.Value
+		if v.IsValid() {
+			fv = v.Field(i)
+		}
+
+		tagParts := strings.Split(f.Tag.Get("mapstructure"), ",")
+		if len(tagParts) > 1 && tagParts[1] == "squash" {
+			for k, s := range structPropertiesSchema(f.Type, fv, path, enums) {
+				props[k] = s
+			}
+			continue
+		}
+
+		name := tagParts[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		name = strings.ToLower(name)
+
+		keyPath := joinKeyPath(path, name)
+		if commandLineOnlyKeys[keyPath] {
+			continue
+		}
+		props[name] = schemaOf(f.Type, fv, keyPath, enums)
+	}
+	return props
+}
+
+func isZero(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	default:
+		return reflect.DeepEqual(v.Interface(), reflect.Zero(v.Type()).Interface())
+	}
+}
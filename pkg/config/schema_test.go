@@ -0,0 +1,69 @@
+package config
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONSchema(t *testing.T) {
+	defaults := NewDefault()
+	defaults.Run.Deadline = time.Minute
+	defaults.Run.AnalyzeTests = true
+
+	schema := JSONSchema(defaults, SchemaEnums{
+		"linters.presets": {"bugs", "style"},
+	}, SchemaEnums{
+		"linters.enable":               {"govet", "godox"},
+		"linters-settings.timeouts":    {"govet", "godox"},
+		"issues.exclude-rules.linters": {"govet", "godox"},
+	})
+
+	// the schema is checked in the JSON form editors use
+	data, err := json.Marshal(schema)
+	require.NoError(t, err)
+	var s struct {
+		Schema string `json:"$schema"`
+	}
+	require.NoError(t, json.Unmarshal(data, &s))
+	assert.Equal(t, "http://json-schema.org/draft-07/schema#", s.Schema)
+
+	prop := func(path ...string) map[string]interface{} {
+		var cur interface{} = schema
+		for _, p := range path {
+			cur = cur.(map[string]interface{})[p]
+			require.NotNil(t, cur, "no %v", path)
+		}
+		return cur.(map[string]interface{})
+	}
+
+	assert.Equal(t, map[string]interface{}{"type": "string", "default": "1m0s"},
+		prop("properties", "run", "properties", "deadline"))
+	assert.Equal(t, map[string]interface{}{"type": "boolean", "default": true},
+		prop("properties", "run", "properties", "tests"))
+	assert.Equal(t, map[string]interface{}{"type": "integer", "default": 120},
+		prop("properties", "linters-settings", "properties", "lll", "properties", "line-length"))
+
+	// names of linters are suggested, names of custom linters are allowed too
+	linterNames := []interface{}{
+		map[string]interface{}{"enum": []string{"govet", "godox"}},
+		map[string]interface{}{"type": "string"},
+	}
+	assert.Equal(t, map[string]interface{}{"type": "string", "anyOf": linterNames},
+		prop("properties", "linters", "properties", "enable", "items"))
+	assert.Equal(t, linterNames,
+		prop("properties", "issues", "properties", "exclude-rules", "items", "properties", "linters", "items")["anyOf"])
+	assert.Equal(t, map[string]interface{}{"anyOf": linterNames},
+		prop("properties", "linters-settings", "properties", "timeouts", "propertyNames"))
+	assert.Equal(t, map[string]interface{}{"type": "string", "enum": []string{"bugs", "style"}},
+		prop("properties", "linters", "properties", "presets", "items"))
+	assert.Contains(t, prop("properties", "issues", "properties", "exclude-rules", "items", "properties"), "path")
+	assert.Equal(t, false, prop("properties", "run")["additionalProperties"])
+	assert.NotContains(t, prop("properties", "run", "properties"), "args")
+	assert.Contains(t, prop("properties", "output", "properties", "format"), "anyOf")
+	assert.Contains(t, prop("properties", "linters-settings", "properties", "gocritic", "properties",
+		"enabled-checks", "items")["enum"], "hugeParam")
+}
@@ -0,0 +1,247 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// UnknownKey is a key of a config file not matching any option of Config
+type UnknownKey struct {
+	Path       string // e.g. linters-settings.gocyclo.min-complexty
+	Suggestion string // the most similar known key at the same level, e.g. min-complexity
+	File       string
+	Line       int // 0 if the line isn't found
+}
+
+func (k UnknownKey) String() string {
+	pos := k.File
+	if k.Line != 0 {
+		pos = fmt.Sprintf("%s:%d", pos, k.Line)
+	}
+
+	ret := fmt.Sprintf("%s: unknown key %s", pos, k.Path)
+	if k.Suggestion != "" {
+		ret += fmt.Sprintf(", did you mean %s?", k.Suggestion)
+	}
+	return ret
+}
+
+// ignoredKeys are top-level keys of settings of other tools, e.g. golangci.com
+var ignoredKeys = map[string]bool{
+	"service": true,
+}
+
+// FindUnknownKeys returns keys of settings read from config files not matching options of Config by
+// mapstructure tags: the key is attributed to the last of files it's found in.
+func FindUnknownKeys(settings map[string]interface{}, files []string) []UnknownKey {
+	var keys []UnknownKey
+	for k, v := range settings {
+		if !ignoredKeys[strings.ToLower(k)] {
+			findUnknownKeys(map[string]interface{}{k: v}, reflect.TypeOf(Config{}), nil, &keys)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].Path < keys[j].Path
+	})
+
+	contents := make([][]byte, len(files))
+	for i, f := range files {
+		contents[i], _ = ioutil.ReadFile(f)
+	}
+
+	for i := range keys {
+		segments := keyPathSegments(keys[i].Path)
+		for j := len(files) - 1; j >= 0; j-- {
+			if line := findKeyLine(contents[j], segments); line != 0 || j == 0 {
+				keys[i].File, keys[i].Line = files[j], line
+				break
+			}
+		}
+	}
+
+	return keys
+}
+
+// structKeys returns config keys of fields of the struct type: keys of squashed embedded structs are inlined
+func structKeys(t reflect.Type) map[string]reflect.Type {
+	ret := map[string]reflect.Type{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tagParts := strings.Split(f.Tag.Get("mapstructure"), ",")
+		if len(tagParts) > 1 && tagParts[1] == "squash" {
+			for k, ft := range structKeys(f.Type) {
+				ret[k] = ft
+			}
+			continue
+		}
+
+		name := tagParts[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		ret[strings.ToLower(name)] = f.Type
+	}
+	return ret
+}
+
+func findUnknownKeys(value interface{}, t reflect.Type, path []string, keys *[]UnknownKey) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		m, ok := stringMap(value)
+		if !ok {
+			return // the type mismatch is reported by decoding
+		}
+
+		known := structKeys(t)
+		for k, v := range m {
+			keyPath := append(path[:len(path):len(path)], k)
+			ft, ok := known[strings.ToLower(k)]
+			if !ok {
+				*keys = append(*keys, UnknownKey{
+					Path:       joinKeyPathSegments(keyPath),
+					Suggestion: suggestKey(strings.ToLower(k), known),
+				})
+				continue
+			}
+			findUnknownKeys(v, ft, keyPath, keys)
+		}
+	case reflect.Map:
+		m, ok := stringMap(value)
+		if !ok {
+			return
+		}
+		for k, v := range m {
+			findUnknownKeys(v, t.Elem(), append(path[:len(path):len(path)], k), keys)
+		}
+	case reflect.Slice:
+		s, ok := value.([]interface{})
+		if !ok {
+			return
+		}
+		for i, v := range s {
+			findUnknownKeys(v, t.Elem(), append(path[:len(path):len(path)], fmt.Sprintf("[%d]", i)), keys)
+		}
+	}
+}
+
+func joinKeyPathSegments(segments []string) string {
+	var b strings.Builder
+	for _, s := range segments {
+		if b.Len() != 0 && !strings.HasPrefix(s, "[") {
+			b.WriteByte('.')
+		}
+		b.WriteString(s)
+	}
+	return b.String()
+}
+
+// keyPathSegments returns keys of the key path without slice indexes
+func keyPathSegments(path string) []string {
+	var ret []string
+	for _, s := range strings.Split(path, ".") {
+		if i := strings.Index(s, "["); i != -1 {
+			s = s[:i]
+		}
+		if s != "" {
+			ret = append(ret, s)
+		}
+	}
+	return ret
+}
+
+// findKeyLine returns the 1-based line of the last key of the key path in a YAML, TOML or JSON file:
+// keys of the path are searched one after another. It returns 0 if the key isn't found.
+func findKeyLine(content []byte, segments []string) int {
+	lines := strings.Split(strings.ToLower(string(content)), "\n")
+	line := 0
+	for _, s := range segments {
+		found := false
+		for ; line < len(lines); line++ {
+			if rest, ok := matchKey(lines[line], s); ok {
+				found = true
+				if strings.HasPrefix(rest, ".") {
+					// a dotted key, e.g. [linters-settings.gocyclo] in TOML: the next key is on the same line
+					lines[line] = rest[1:]
+					line--
+				}
+				break
+			}
+		}
+		if !found {
+			return 0
+		}
+		line++
+	}
+
+	return line
+}
+
+// matchKey checks whether the line starts with the key and returns the rest of the line after the key
+func matchKey(line, key string) (string, bool) {
+	line = strings.TrimLeft(strings.TrimSpace(line), "-[ \t")
+	for _, quote := range []string{"", `"`, "'"} {
+		rest := strings.TrimPrefix(line, quote+key+quote)
+		if rest == line {
+			continue
+		}
+		rest = strings.TrimSpace(rest)
+		if strings.HasPrefix(rest, ":") || strings.HasPrefix(rest, "=") ||
+			strings.HasPrefix(rest, ".") || strings.HasPrefix(rest, "]") {
+			return rest, true
+		}
+	}
+	return "", false
+}
+
+// suggestKey returns the known key most similar to the unknown one or an empty string if no key is similar enough
+func suggestKey(key string, known map[string]reflect.Type) string {
+	best := ""
+	bestDistance := len(key)/3 + 1
+	for k := range known {
+		if d := levenshtein(key, k); d < bestDistance || d == bestDistance && best != "" && k < best {
+			best, bestDistance = k, d
+		}
+	}
+	return best
+}
+
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = minInt(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+func minInt(values ...int) int {
+	ret := values[0]
+	for _, v := range values[1:] {
+		if v < ret {
+			ret = v
+		}
+	}
+	return ret
+}
@@ -0,0 +1,69 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindUnknownKeys(t *testing.T) {
+	dir, err := ioutil.TempDir("", "golangci_lint_unknown_keys_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	writeTestFiles(t, dir, map[string]string{
+		"base.yml": `
+linters-settings:
+  gocyclo:
+    min-complexty: 15
+`,
+		".golangci.yml": `
+extends: [base.yml]
+run:
+  deadline: 1m
+linters-setting:
+  gocyclo:
+    min-complexity: 20
+issues:
+  exclude-rules:
+    - path: _test\.go
+      linter: [gocyclo]
+linters-settings:
+  govet:
+    settings:
+      printf:
+        funcs: [Infof]
+service:
+  golangci-lint-version: 1.17.x
+`,
+	})
+
+	base, configFile := filepath.Join(dir, "base.yml"), filepath.Join(dir, ".golangci.yml")
+	settings, err := ReadFileSettings(configFile)
+	require.NoError(t, err)
+
+	keys := FindUnknownKeys(settings.Settings, settings.Files)
+	assert.Equal(t, []UnknownKey{
+		{Path: "issues.exclude-rules[0].linter", Suggestion: "linters", File: configFile, Line: 11},
+		{Path: "linters-setting", Suggestion: "linters-settings", File: configFile, Line: 5},
+		{Path: "linters-settings.gocyclo.min-complexty", Suggestion: "min-complexity", File: base, Line: 4},
+	}, keys)
+	assert.Equal(t, configFile+":5: unknown key linters-setting, did you mean linters-settings?", keys[1].String())
+
+	v := viper.New()
+	v.SetConfigType("toml")
+	content := "[run]\nconcurency = 2\n\n[linters-settings.gocyclo]\nmin-complexity = 20\nunknown-option = 1\n"
+	require.NoError(t, v.ReadConfig(strings.NewReader(content)))
+	writeTestFiles(t, dir, map[string]string{".golangci.toml": content})
+
+	keys = FindUnknownKeys(v.AllSettings(), []string{filepath.Join(dir, ".golangci.toml")})
+	require.Len(t, keys, 2)
+	assert.Equal(t, UnknownKey{Path: "linters-settings.gocyclo.unknown-option", File: filepath.Join(dir, ".golangci.toml"), Line: 6}, keys[0])
+	assert.Equal(t, UnknownKey{Path: "run.concurency", Suggestion: "concurrency", File: filepath.Join(dir, ".golangci.toml"), Line: 2}, keys[1])
+}
@@ -0,0 +1,146 @@
+package daemon
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/report"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+// Request is a lint request sent by `golangci-lint run --daemon`: every connection
+// to the daemon socket contains one JSON-encoded request and one JSON-encoded response.
+type Request struct {
+	Wd            string // working directory of the client: it must be the same as the daemon one
+	Args          []string
+	Files         bool
+	StdinFilename string
+	Overlay       map[string][]byte // contents of files replacing files on disk, including stdin
+	Flags         []string          // names of options set by the client: the daemon warns about ignored ones
+}
+
+// Response is a response of the daemon for Request
+type Response struct {
+	Issues []result.Issue
+	Report *report.Data
+
+	Error     string // non-empty if linting failed
+	ErrorCode int    // exit code for Error
+}
+
+// Handler lints by the request
+type Handler func(ctx context.Context, req *Request) *Response
+
+// DefaultSocketPath returns a path of the socket of the daemon for the working directory:
+// every directory has its own daemon.
+func DefaultSocketPath(wd string) string {
+	h := sha256.Sum256([]byte(wd))
+	return filepath.Join(os.TempDir(), fmt.Sprintf("golangci-lint-%x.sock", h[:8]))
+}
+
+// Send sends the request to the daemon listening on the unix socket and returns its response
+func Send(ctx context.Context, socketPath string, req *Request) (*Response, error) {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "unix", socketPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to connect to daemon: run `golangci-lint serve` in %s", req.Wd)
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err = conn.SetDeadline(deadline); err != nil {
+			return nil, errors.Wrap(err, "failed to set deadline of daemon connection")
+		}
+	}
+
+	if err = json.NewEncoder(conn).Encode(req); err != nil {
+		return nil, errors.Wrap(err, "failed to send request to daemon")
+	}
+
+	var resp Response
+	if err = json.NewDecoder(conn).Decode(&resp); err != nil {
+		return nil, errors.Wrap(err, "failed to read response of daemon")
+	}
+
+	return &resp, nil
+}
+
+// Server serves lint requests on a unix socket
+type Server struct {
+	listener net.Listener
+	handler  Handler
+	log      logutils.Log
+	wg       sync.WaitGroup
+}
+
+// Listen creates the unix socket: it fails if another daemon is listening on it.
+// A socket file left by a crashed daemon is removed.
+func Listen(socketPath string, handler Handler, log logutils.Log) (*Server, error) {
+	if conn, err := net.Dial("unix", socketPath); err == nil {
+		conn.Close()
+		return nil, fmt.Errorf("daemon is already running on %s", socketPath)
+	}
+	if err := os.Remove(socketPath); err != nil && !os.IsNotExist(err) {
+		return nil, errors.Wrapf(err, "failed to remove stale socket %s", socketPath)
+	}
+
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to listen on %s", socketPath)
+	}
+
+	return &Server{
+		listener: listener,
+		handler:  handler,
+		log:      log,
+	}, nil
+}
+
+// Serve accepts connections until Close is called
+func (s *Server) Serve(ctx context.Context) error {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				s.wg.Wait()
+				return nil
+			}
+			return errors.Wrap(err, "failed to accept connection")
+		}
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.serveConn(ctx, conn)
+		}()
+	}
+}
+
+// Close stops accepting connections and removes the socket
+func (s *Server) Close() error {
+	return s.listener.Close()
+}
+
+func (s *Server) serveConn(ctx context.Context, conn net.Conn) {
+	defer conn.Close()
+
+	var req Request
+	if err := json.NewDecoder(conn).Decode(&req); err != nil {
+		s.log.Warnf("Can't read daemon request: %s", err)
+		return
+	}
+
+	resp := s.handler(ctx, &req)
+	if err := json.NewEncoder(conn).Encode(resp); err != nil {
+		s.log.Warnf("Can't send daemon response: %s", err)
+	}
+}
@@ -0,0 +1,106 @@
+package daemon
+
+import (
+	"context"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func TestSendRequest(t *testing.T) {
+	dir, err := ioutil.TempDir("", "golangci_lint_daemon_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	socketPath := filepath.Join(dir, "daemon.sock")
+	log := logutils.NewStderrLog("daemon")
+	handler := func(ctx context.Context, req *Request) *Response {
+		return &Response{
+			Issues: []result.Issue{{
+				FromLinter: "test",
+				Text:       string(req.Overlay["a.go"]),
+				Pos:        token.Position{Filename: req.Args[0], Line: 1},
+			}},
+		}
+	}
+
+	server, err := Listen(socketPath, handler, log)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error)
+	go func() {
+		served <- server.Serve(ctx)
+	}()
+
+	_, err = Listen(socketPath, handler, log)
+	assert.Error(t, err, "second daemon must not listen on the same socket")
+
+	resp, err := Send(context.Background(), socketPath, &Request{
+		Args:    []string{"a.go"},
+		Overlay: map[string][]byte{"a.go": []byte("text")},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Issues, 1)
+	assert.Equal(t, "text", resp.Issues[0].Text)
+	assert.Equal(t, "a.go", resp.Issues[0].FilePath())
+
+	cancel()
+	require.NoError(t, server.Close())
+	require.NoError(t, <-served)
+
+	_, err = Send(context.Background(), socketPath, &Request{})
+	assert.Error(t, err)
+}
+
+func TestWatcher(t *testing.T) {
+	dir, err := ioutil.TempDir("", "golangci_lint_watcher_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	changes := make(chan string, 10)
+	w, err := NewWatcher(dir, []string{`(^|/)testdata($|/)`}, logutils.NewStderrLog("watcher"), func(path string) {
+		changes <- path
+	})
+	require.NoError(t, err)
+	defer w.Close()
+
+	expectChange := func(path string) {
+		timeout := time.After(5 * time.Second)
+		for {
+			select {
+			case changed := <-changes:
+				if changed == path {
+					return
+				}
+				assert.NotContains(t, changed, "testdata"+string(filepath.Separator))
+			case <-timeout:
+				t.Fatalf("no change of %s", path)
+			}
+		}
+	}
+
+	// files in skipped directories aren't watched
+	skippedDir := filepath.Join(dir, "testdata")
+	require.NoError(t, os.Mkdir(skippedDir, os.ModePerm))
+	expectChange(skippedDir)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(skippedDir, "a.go"), []byte("package testdata"), os.ModePerm))
+
+	subDir := filepath.Join(dir, "sub")
+	require.NoError(t, os.Mkdir(subDir, os.ModePerm))
+	expectChange(subDir)
+
+	// new directories are watched too
+	filePath := filepath.Join(subDir, "a.go")
+	require.NoError(t, ioutil.WriteFile(filePath, []byte("package sub"), os.ModePerm))
+	expectChange(filePath)
+}
@@ -0,0 +1,133 @@
+package daemon
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/pkg/errors"
+
+	"github.com/golangci/golangci-lint/pkg/logutils"
+)
+
+// Watcher watches all directories under the root recursively and
+// reports paths of changed files. Hidden directories and directories matching
+// skip patterns (like option --skip-dirs) aren't watched.
+type Watcher struct {
+	w        *fsnotify.Watcher
+	root     string
+	skipDirs []*regexp.Regexp
+	log      logutils.Log
+	onChange func(path string)
+	done     chan struct{}
+}
+
+func NewWatcher(root string, skipDirs []string, log logutils.Log, onChange func(path string)) (*Watcher, error) {
+	var skipDirsRe []*regexp.Regexp
+	for _, p := range skipDirs {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, errors.Wrapf(err, "can't compile regexp %q", p)
+		}
+		skipDirsRe = append(skipDirsRe, re)
+	}
+
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create file watcher")
+	}
+
+	ret := &Watcher{
+		w:        w,
+		root:     root,
+		skipDirs: skipDirsRe,
+		log:      log,
+		onChange: onChange,
+		done:     make(chan struct{}),
+	}
+	if err = ret.addDir(root); err != nil {
+		w.Close()
+		return nil, err
+	}
+
+	go ret.run()
+	return ret, nil
+}
+
+func (w *Watcher) isSkippedDir(path string) bool {
+	name := filepath.Base(path)
+	if strings.HasPrefix(name, ".") && name != "." && name != ".." {
+		return true
+	}
+
+	// skip patterns match paths relative to the root as they match paths relative to the working directory
+	relPath, err := filepath.Rel(w.root, path)
+	if err != nil {
+		return false
+	}
+	for _, re := range w.skipDirs {
+		if re.MatchString(relPath) {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *Watcher) addDir(root string) error {
+	return filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if path == root {
+				return err
+			}
+			return nil // the directory can be removed while walking
+		}
+		if !info.IsDir() {
+			return nil
+		}
+		if path != root && w.isSkippedDir(path) {
+			return filepath.SkipDir
+		}
+
+		if err := w.w.Add(path); err != nil {
+			return errors.Wrapf(err, "failed to watch %s", path)
+		}
+		return nil
+	})
+}
+
+func (w *Watcher) run() {
+	defer close(w.done)
+
+	for {
+		select {
+		case event, ok := <-w.w.Events:
+			if !ok {
+				return
+			}
+
+			if event.Op&fsnotify.Create != 0 {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() && !w.isSkippedDir(event.Name) {
+					if err = w.addDir(event.Name); err != nil {
+						w.log.Warnf("Can't watch new directory: %s", err)
+					}
+				}
+			}
+			if event.Op&fsnotify.Chmod != event.Op { // ignore only chmod
+				w.onChange(event.Name)
+			}
+		case err, ok := <-w.w.Errors:
+			if !ok {
+				return
+			}
+			w.log.Warnf("File watcher error: %s", err)
+		}
+	}
+}
+
+func (w *Watcher) Close() error {
+	err := w.w.Close()
+	<-w.done
+	return err
+}
@@ -11,14 +11,35 @@ import (
 )
 
 type FileCache struct {
-	files sync.Map
+	files   sync.Map
+	overlay map[string][]byte // absolute file path -> contents replacing the file on disk
 }
 
 func NewFileCache() *FileCache {
 	return &FileCache{}
 }
 
+// SetOverlay makes the cache return the given contents instead of contents of files on disk,
+// e.g. contents of a file from stdin. Overlay file paths must be normalized by OverlayPath.
+func (fc *FileCache) SetOverlay(overlay map[string][]byte) {
+	fc.overlay = overlay
+}
+
+// Overlay returns contents replacing contents of files on disk
+func (fc *FileCache) Overlay() map[string][]byte {
+	return fc.overlay
+}
+
+// Clear forgets contents of all files: they are read again on the next access
+func (fc *FileCache) Clear() {
+	fc.files = sync.Map{}
+}
+
 func (fc *FileCache) GetFileBytes(filePath string) ([]byte, error) {
+	if fileBytes, ok := OverlayContent(fc.overlay, filePath); ok {
+		return fileBytes, nil
+	}
+
 	cachedBytes, ok := fc.files.Load(filePath)
 	if ok {
 		return cachedBytes.([]byte), nil
@@ -21,6 +21,11 @@ func NewLineCache(fc *FileCache) *LineCache {
 	}
 }
 
+// Clear forgets lines of all files
+func (lc *LineCache) Clear() {
+	lc.files = sync.Map{}
+}
+
 // GetLine returns a index1-th (1-based index) line from the file on filePath
 func (lc *LineCache) GetLine(filePath string, index1 int) (string, error) {
 	if index1 == 0 { // some linters, e.g. gosec can do it: it really means first line
@@ -0,0 +1,80 @@
+package fsutils
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// overlayJSON is the format of `go build -overlay` files
+type overlayJSON struct {
+	Replace map[string]string
+}
+
+// OverlayPath returns a key of overlays for the file path: the absolute path with evaluated symlinks.
+// Symlinks are evaluated only if the file exists.
+func OverlayPath(path string) (string, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to abs-ify %s", path)
+	}
+
+	if evaledPath, err := EvalSymlinks(absPath); err == nil {
+		absPath = evaledPath
+	}
+
+	return absPath, nil
+}
+
+// OverlayContent returns contents of the file in the overlay: the file path is normalized by OverlayPath
+// as keys of overlays are, e.g. for files below symlinked directories
+func OverlayContent(overlay map[string][]byte, path string) ([]byte, bool) {
+	if len(overlay) == 0 {
+		return nil, false
+	}
+
+	key, err := OverlayPath(path)
+	if err != nil {
+		return nil, false
+	}
+	content, ok := overlay[key]
+	return content, ok
+}
+
+// ReadOverlayFile reads an overlay file in the format of `go build -overlay`:
+// {"Replace": {"file.go": "replacement.go"}}. It returns contents of
+// replacement files by normalized paths of replaced files.
+func ReadOverlayFile(path string) (map[string][]byte, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read overlay file")
+	}
+
+	var o overlayJSON
+	if err = json.Unmarshal(data, &o); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse overlay file %s", path)
+	}
+
+	overlay := map[string][]byte{}
+	for from, to := range o.Replace {
+		if to == "" {
+			return nil, fmt.Errorf("overlay file %s: deleting of %s isn't supported", path, from)
+		}
+
+		content, err := ioutil.ReadFile(to)
+		if err != nil {
+			return nil, errors.Wrapf(err, "overlay file %s: failed to read replacement of %s", path, from)
+		}
+
+		key, err := OverlayPath(from)
+		if err != nil {
+			return nil, err
+		}
+		overlay[key] = content
+	}
+
+	return overlay, nil
+}
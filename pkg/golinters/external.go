@@ -0,0 +1,257 @@
+package golinters
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"go/token"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/golangci/golangci-lint/pkg/lint/linter"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+const (
+	ExternalModeFiles   = "files"
+	ExternalModePackage = "package"
+
+	// externalFilesArg is expanded into file names in a command
+	externalFilesArg = "{files}"
+	// externalDirArg is expanded into a package directory in a command
+	externalDirArg = "{dir}"
+)
+
+// External runs an arbitrary command and parses its output
+// line by line into issues by regexps.
+type External struct {
+	name, desc string
+	command    []string
+	mode       string
+	patterns   []*regexp.Regexp
+}
+
+// NewExternal creates an external linter. Patterns must have named groups
+// `file`, `line` and `message`, groups `col` and `severity` (or errorformat
+// `type`) are optional.
+func NewExternal(name, desc string, command []string, mode string, patterns []*regexp.Regexp) *External {
+	return &External{
+		name:     name,
+		desc:     desc,
+		command:  command,
+		mode:     mode,
+		patterns: patterns,
+	}
+}
+
+func (e External) Name() string {
+	return e.name
+}
+
+func (e External) Desc() string {
+	return e.desc
+}
+
+func (e External) Run(ctx context.Context, lintCtx *linter.Context) ([]result.Issue, error) {
+	if e.mode != ExternalModePackage {
+		files := getAllFileNames(lintCtx)
+		if len(files) == 0 {
+			return nil, nil
+		}
+		return e.runCommand(ctx, "", files)
+	}
+
+	var issues []result.Issue
+	seenDirs := map[string]bool{} // test packages have the same directory
+	for _, pkg := range lintCtx.Packages {
+		if len(pkg.GoFiles) == 0 {
+			continue
+		}
+
+		dir := filepath.Dir(pkg.GoFiles[0])
+		if seenDirs[dir] {
+			continue
+		}
+		seenDirs[dir] = true
+
+		pkgIssues, err := e.runCommand(ctx, dir, pkg.GoFiles)
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, pkgIssues...)
+	}
+
+	return issues, nil
+}
+
+func (e External) runCommand(ctx context.Context, dir string, files []string) ([]result.Issue, error) {
+	args := e.expandArgs(dir, files)
+
+	var out bytes.Buffer
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Dir = dir
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	runErr := cmd.Run()
+
+	issues, err := e.parseOutput(out.Bytes(), dir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse output of %s", args[0])
+	}
+
+	// linters usually exit with non-zero code when they find issues
+	if runErr != nil && len(issues) == 0 {
+		if outText := strings.TrimSpace(out.String()); outText != "" {
+			runErr = fmt.Errorf("%s: %s", runErr, outText)
+		}
+		return nil, errors.Wrapf(runErr, "failed to run %s", strings.Join(args, " "))
+	}
+
+	return issues, nil
+}
+
+func (e External) expandArgs(dir string, files []string) []string {
+	if dir == "" {
+		dir = "."
+	}
+
+	var args []string
+	for _, arg := range e.command {
+		if arg == externalFilesArg {
+			args = append(args, files...)
+			continue
+		}
+		args = append(args, strings.Replace(arg, externalDirArg, dir, -1))
+	}
+
+	return args
+}
+
+func (e External) parseOutput(out []byte, dir string) ([]result.Issue, error) {
+	var issues []result.Issue
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		if issue := e.parseLine(scanner.Text(), dir); issue != nil {
+			issues = append(issues, *issue)
+		}
+	}
+
+	return issues, scanner.Err()
+}
+
+func (e External) parseLine(line, dir string) *result.Issue {
+	for _, re := range e.patterns {
+		m := re.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+
+		groups := map[string]string{}
+		for i, name := range re.SubexpNames() {
+			if name != "" && m[i] != "" {
+				groups[name] = m[i]
+			}
+		}
+
+		lineNumber, _ := strconv.Atoi(groups["line"])
+		col, _ := strconv.Atoi(groups["col"])
+		if groups["file"] == "" || lineNumber == 0 || groups["message"] == "" {
+			continue
+		}
+
+		severity := groups["severity"]
+		if severity == "" {
+			severity = externalTypeSeverities[strings.ToLower(groups["type"])]
+		}
+
+		filename := groups["file"]
+		if dir != "" && !filepath.IsAbs(filename) {
+			filename = filepath.Join(dir, filename)
+		}
+
+		return &result.Issue{
+			FromLinter: e.name,
+			Text:       strings.TrimSpace(groups["message"]),
+			Severity:   severity,
+			Pos: token.Position{
+				Filename: filename,
+				Line:     lineNumber,
+				Column:   col,
+			},
+		}
+	}
+
+	return nil
+}
+
+// externalTypeSeverities maps errorformat %t characters to severities
+var externalTypeSeverities = map[string]string{
+	"e": "error",
+	"w": "warning",
+	"i": "info",
+	"n": "note",
+}
+
+// ErrorFormatToRegexp converts a vim errorformat into a regexp for NewExternal.
+// Only the next items are supported: %f (file), %l (line), %c (column),
+// %m (message), %t (severity character), %s (search text), %*X (skipped
+// scanf-like item) and %% (percent sign).
+func ErrorFormatToRegexp(errorFormat string) (*regexp.Regexp, error) {
+	var re strings.Builder
+	re.WriteString("^")
+
+	for i := 0; i < len(errorFormat); i++ {
+		c := errorFormat[i]
+		if c != '%' {
+			re.WriteString(regexp.QuoteMeta(string(c)))
+			continue
+		}
+
+		i++
+		if i == len(errorFormat) {
+			return nil, fmt.Errorf("errorformat %q ends with %%", errorFormat)
+		}
+
+		switch errorFormat[i] {
+		case 'f':
+			// on Windows file names contain `C:`
+			re.WriteString(`(?P<file>(?:[a-zA-Z]:)?[^:]+)`)
+		case 'l':
+			re.WriteString(`(?P<line>\d+)`)
+		case 'c':
+			re.WriteString(`(?P<col>\d+)`)
+		case 'm':
+			re.WriteString(`(?P<message>.+)`)
+		case 't':
+			re.WriteString(`(?P<type>[a-zA-Z])`)
+		case 's':
+			re.WriteString(`.+`)
+		case '*':
+			i++
+			if i == len(errorFormat) {
+				return nil, fmt.Errorf("errorformat %q ends with %%*", errorFormat)
+			}
+			switch errorFormat[i] {
+			case 'd':
+				re.WriteString(`\d+`)
+			case 's':
+				re.WriteString(`\S+`)
+			default:
+				return nil, fmt.Errorf("unsupported item %%*%c in errorformat %q", errorFormat[i], errorFormat)
+			}
+		case '%':
+			re.WriteString("%")
+		default:
+			return nil, fmt.Errorf("unsupported item %%%c in errorformat %q", errorFormat[i], errorFormat)
+		}
+	}
+
+	re.WriteString("$")
+	return regexp.Compile(re.String())
+}
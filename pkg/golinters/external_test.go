@@ -0,0 +1,63 @@
+package golinters
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorFormatToRegexp(t *testing.T) {
+	re, err := ErrorFormatToRegexp("%f:%l:%c: %t%*[a-z]: %m")
+	assert.Error(t, err)
+	assert.Nil(t, re)
+
+	re, err = ErrorFormatToRegexp("%f:%l:%c: %m (100%%)")
+	require.NoError(t, err)
+	assert.True(t, re.MatchString("a.go:1:2: bad (100%)"))
+	assert.False(t, re.MatchString("a.go:1:2: bad"))
+}
+
+func TestExternalParseOutput(t *testing.T) {
+	ef, err := ErrorFormatToRegexp("%f:%l:%c: %t%*s %m")
+	require.NoError(t, err)
+
+	e := NewExternal("ext", "", nil, ExternalModePackage, []*regexp.Regexp{
+		regexp.MustCompile(`^In (?P<file>\S+) line (?P<line>\d+): (?P<message>.+)$`),
+		ef,
+	})
+
+	const out = `In a.sh line 3: Double quote to prevent globbing
+b.go:10:5: warning: unused variable
+/abs/c.go:1:1: error: syntax error
+some noise
+d.go:0:1: error: line 0 is invalid
+`
+
+	issues, err := e.parseOutput([]byte(out), "/pkg")
+	require.NoError(t, err)
+	require.Len(t, issues, 3)
+
+	assert.Equal(t, "ext", issues[0].FromLinter)
+	assert.Equal(t, "Double quote to prevent globbing", issues[0].Text)
+	assert.Equal(t, "/pkg/a.sh", issues[0].FilePath())
+	assert.Equal(t, 3, issues[0].Line())
+	assert.Equal(t, 0, issues[0].Column())
+	assert.Equal(t, "", issues[0].Severity)
+
+	assert.Equal(t, "unused variable", issues[1].Text)
+	assert.Equal(t, "/pkg/b.go", issues[1].FilePath())
+	assert.Equal(t, 10, issues[1].Line())
+	assert.Equal(t, 5, issues[1].Column())
+	assert.Equal(t, "warning", issues[1].Severity)
+
+	assert.Equal(t, "/abs/c.go", issues[2].FilePath())
+	assert.Equal(t, "error", issues[2].Severity)
+}
+
+func TestExternalExpandArgs(t *testing.T) {
+	e := NewExternal("ext", "", []string{"tool", "-C", "{dir}/sql", "{files}"}, ExternalModeFiles, nil)
+	assert.Equal(t, []string{"tool", "-C", "./sql", "a.go", "b.go"}, e.expandArgs("", []string{"a.go", "b.go"}))
+	assert.Equal(t, []string{"tool", "-C", "/pkg/sql"}, e.expandArgs("/pkg", nil))
+}
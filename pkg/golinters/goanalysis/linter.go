@@ -114,9 +114,10 @@ func (lnt Linter) Run(ctx context.Context, lintCtx *linter.Context) ([]result.Is
 		return nil, errors.Wrap(err, "failed to configure analyzers")
 	}
 
-	runner := newRunner(lnt.name, lintCtx.Log.Child("goanalysis"), lintCtx.PkgCache, lintCtx.LoadGuard)
+	runner := newRunner(lnt.name, lintCtx.Log.Child("goanalysis"), lintCtx.PkgCache, lintCtx.LoadGuard,
+		lintCtx.FileCache.Overlay())
 
-	diags, errs := runner.run(lnt.analyzers, lintCtx.Packages)
+	diags, errs := runner.run(ctx, lnt.analyzers, lintCtx.Packages)
 	for i := 1; i < len(errs); i++ {
 		lintCtx.Log.Warnf("%s error: %s", lnt.Name(), errs[i])
 	}
@@ -128,9 +129,10 @@ func (lnt Linter) Run(ctx context.Context, lintCtx *linter.Context) ([]result.Is
 	for i := range diags {
 		diag := &diags[i]
 		issues = append(issues, result.Issue{
-			FromLinter: lnt.Name(),
-			Text:       fmt.Sprintf("%s: %s", diag.Analyzer.Name, diag.Message),
-			Pos:        diag.Position,
+			FromLinter:  lnt.Name(),
+			Text:        fmt.Sprintf("%s: %s", diag.Analyzer.Name, diag.Message),
+			Pos:         diag.Position,
+			Replacement: diag.getReplacement(),
 		})
 	}
 
@@ -46,9 +46,10 @@ func (ml MetaLinter) Run(ctx context.Context, lintCtx *linter.Context) ([]result
 		allAnalyzers = append(allAnalyzers, linter.analyzers...)
 	}
 
-	runner := newRunner("metalinter", lintCtx.Log.Child("goanalysis"), lintCtx.PkgCache, lintCtx.LoadGuard)
+	runner := newRunner("metalinter", lintCtx.Log.Child("goanalysis"), lintCtx.PkgCache, lintCtx.LoadGuard,
+		lintCtx.FileCache.Overlay())
 
-	diags, errs := runner.run(allAnalyzers, lintCtx.Packages)
+	diags, errs := runner.run(ctx, allAnalyzers, lintCtx.Packages)
 	for i := 1; i < len(errs); i++ {
 		lintCtx.Log.Warnf("go/analysis metalinter error: %s", errs[i])
 	}
@@ -60,9 +61,10 @@ func (ml MetaLinter) Run(ctx context.Context, lintCtx *linter.Context) ([]result
 	for i := range diags {
 		diag := &diags[i]
 		issues = append(issues, result.Issue{
-			FromLinter: ml.analyzerToLinterName[diag.Analyzer],
-			Text:       fmt.Sprintf("%s: %s", diag.Analyzer, diag.Message),
-			Pos:        diag.Position,
+			FromLinter:  ml.analyzerToLinterName[diag.Analyzer],
+			Text:        fmt.Sprintf("%s: %s", diag.Analyzer, diag.Message),
+			Pos:         diag.Position,
+			Replacement: diag.getReplacement(),
 		})
 	}
 
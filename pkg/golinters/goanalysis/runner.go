@@ -11,6 +11,7 @@ package goanalysis
 
 import (
 	"bytes"
+	"context"
 	"encoding/gob"
 	"fmt"
 	"go/ast"
@@ -35,8 +36,10 @@ import (
 	"github.com/golangci/golangci-lint/internal/errorutil"
 	"github.com/golangci/golangci-lint/internal/pkgcache"
 
+	"github.com/golangci/golangci-lint/pkg/fsutils"
 	"github.com/golangci/golangci-lint/pkg/golinters/goanalysis/load"
 	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
 
 	"github.com/pkg/errors"
 
@@ -72,6 +75,48 @@ type Diagnostic struct {
 	analysis.Diagnostic
 	Analyzer *analysis.Analyzer
 	Position token.Position
+
+	// TextEdits are edits of the first suggested fix with resolved positions
+	TextEdits []result.TextEdit
+}
+
+func (diag *Diagnostic) getReplacement() *result.Replacement {
+	if len(diag.TextEdits) == 0 {
+		return nil
+	}
+
+	return &result.Replacement{
+		TextEdits: diag.TextEdits,
+	}
+}
+
+// extractTextEdits returns edits of the first suggested fix: suggested fixes are alternatives
+// and can't be applied together
+func extractTextEdits(fset *token.FileSet, diag *analysis.Diagnostic) []result.TextEdit {
+	if len(diag.SuggestedFixes) == 0 {
+		return nil
+	}
+
+	var ret []result.TextEdit
+	for _, edit := range diag.SuggestedFixes[0].TextEdits {
+		start := fset.Position(edit.Pos)
+		end := start
+		if edit.End.IsValid() {
+			end = fset.Position(edit.End)
+		}
+		if !start.IsValid() || end.Filename != start.Filename || end.Offset < start.Offset {
+			return nil // don't apply partial fixes
+		}
+
+		ret = append(ret, result.TextEdit{
+			Filename:  start.Filename,
+			Offset:    start.Offset,
+			EndOffset: end.Offset,
+			NewText:   string(edit.NewText),
+		})
+	}
+
+	return ret
 }
 
 type runner struct {
@@ -79,14 +124,17 @@ type runner struct {
 	prefix    string // ensure unique analyzer names
 	pkgCache  *pkgcache.Cache
 	loadGuard *load.Guard
+	overlay   map[string][]byte // contents replacing files on disk
 }
 
-func newRunner(prefix string, logger logutils.Log, pkgCache *pkgcache.Cache, loadGuard *load.Guard) *runner {
+func newRunner(prefix string, logger logutils.Log, pkgCache *pkgcache.Cache, loadGuard *load.Guard,
+	overlay map[string][]byte) *runner {
 	return &runner{
 		prefix:    prefix,
 		log:       logger,
 		pkgCache:  pkgCache,
 		loadGuard: loadGuard,
+		overlay:   overlay,
 	}
 }
 
@@ -96,11 +144,13 @@ func newRunner(prefix string, logger logutils.Log, pkgCache *pkgcache.Cache, loa
 // It provides most of the logic for the main functions of both the
 // singlechecker and the multi-analysis commands.
 // It returns the appropriate exit code.
+// It stops loading and analyzing packages when ctx is done and returns its error.
 //nolint:gocyclo
-func (r *runner) run(analyzers []*analysis.Analyzer, initialPackages []*packages.Package) ([]Diagnostic, []error) {
+func (r *runner) run(ctx context.Context, analyzers []*analysis.Analyzer,
+	initialPackages []*packages.Package) ([]Diagnostic, []error) {
 	defer r.pkgCache.Trim()
 
-	roots, err := r.analyze(initialPackages, analyzers)
+	roots, err := r.analyze(ctx, initialPackages, analyzers)
 	if err != nil {
 		return nil, []error{err}
 	}
@@ -108,7 +158,8 @@ func (r *runner) run(analyzers []*analysis.Analyzer, initialPackages []*packages
 	return extractDiagnostics(roots)
 }
 
-func (r *runner) analyze(pkgs []*packages.Package, analyzers []*analysis.Analyzer) ([]*action, error) {
+func (r *runner) analyze(ctx context.Context, pkgs []*packages.Package,
+	analyzers []*analysis.Analyzer) ([]*action, error) {
 	// Construct the action graph.
 
 	// Each graph node (action) is one unit of analysis.
@@ -187,16 +238,23 @@ func (r *runner) analyze(pkgs []*packages.Package, analyzers []*analysis.Analyze
 		allActions = append(allActions, act)
 	}
 
-	if err := r.loadPackagesAndFacts(allActions, initialPkgs); err != nil {
+	if err := r.loadPackagesAndFacts(ctx, allActions, initialPkgs); err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
 		return nil, errors.Wrap(err, "failed to load packages")
 	}
 
-	r.runActionsAnalysis(allActions)
+	r.runActionsAnalysis(ctx, allActions)
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
 
 	return roots, nil
 }
 
-func (r *runner) loadPackagesAndFacts(actions []*action, initialPkgs map[*packages.Package]bool) error {
+func (r *runner) loadPackagesAndFacts(ctx context.Context, actions []*action,
+	initialPkgs map[*packages.Package]bool) error {
 	defer func(from time.Time) {
 		debugf("Loading packages and facts took %s", time.Since(from))
 	}(time.Now())
@@ -228,6 +286,7 @@ func (r *runner) loadPackagesAndFacts(actions []*action, initialPkgs map[*packag
 			log:       r.log,
 			actions:   actionPerPkg[pkg],
 			loadGuard: r.loadGuard,
+			overlay:   r.overlay,
 		}
 	}
 	for _, act := range actions {
@@ -245,6 +304,11 @@ func (r *runner) loadPackagesAndFacts(actions []*action, initialPkgs map[*packag
 			defer wg.Done()
 
 			lp.waitUntilImportsLoaded()
+			if ctx.Err() != nil {
+				close(lp.doneCh) // unblock packages importing this package
+				errCh <- ctx.Err()
+				return
+			}
 			loadSem <- struct{}{}
 
 			if err := lp.loadWithFacts(); err != nil {
@@ -263,7 +327,7 @@ func (r *runner) loadPackagesAndFacts(actions []*action, initialPkgs map[*packag
 	return nil
 }
 
-func (r *runner) runActionsAnalysis(actions []*action) {
+func (r *runner) runActionsAnalysis(ctx context.Context, actions []*action) {
 	// Execute the graph in parallel.
 	debugf("Running %d actions in parallel", len(actions))
 	var wg sync.WaitGroup
@@ -278,7 +342,7 @@ func (r *runner) runActionsAnalysis(actions []*action) {
 				}
 				wg.Done()
 			}()
-			act.analyze()
+			act.analyze(ctx)
 		}(act)
 	}
 	wg.Wait()
@@ -332,7 +396,12 @@ func extractDiagnostics(roots []*action) (retDiags []Diagnostic, retErrors []err
 				}
 				seen[k] = true
 
-				retDiags = append(retDiags, Diagnostic{Diagnostic: diag, Analyzer: act.a, Position: posn})
+				retDiags = append(retDiags, Diagnostic{
+					Diagnostic: diag,
+					Analyzer:   act.a,
+					Position:   posn,
+					TextEdits:  extractTextEdits(act.pkg.Fset, &diag),
+				})
 			}
 		}
 	}
@@ -421,7 +490,7 @@ func (act *action) loadCachedFacts() bool {
 	return res
 }
 
-func (act *action) analyze() {
+func (act *action) analyze(ctx context.Context) {
 	defer close(act.analysisDoneCh) // unblock actions depending from this action
 
 	if !act.needAnalyzeSource {
@@ -432,6 +501,9 @@ func (act *action) analyze() {
 	for _, dep := range act.deps {
 		<-dep.analysisDoneCh
 	}
+	if ctx.Err() != nil {
+		return // the caller returns the error of ctx
+	}
 
 	// TODO(adonovan): uncomment this during profiling.
 	// It won't build pre-go1.11 but conditional compilation
@@ -818,6 +890,7 @@ type loadingPackage struct {
 	actions   []*action // all actions with this package
 	wasLoaded bool
 	loadGuard *load.Guard
+	overlay   map[string][]byte
 }
 
 func (lp *loadingPackage) loadFromSource() error {
@@ -842,7 +915,11 @@ func (lp *loadingPackage) loadFromSource() error {
 	// bookkeeping and potentially false sharing of cache lines.
 	pkg.Syntax = make([]*ast.File, len(pkg.CompiledGoFiles))
 	for i, file := range pkg.CompiledGoFiles {
-		f, err := parser.ParseFile(pkg.Fset, file, nil, parser.ParseComments)
+		var src interface{}
+		if content, ok := fsutils.OverlayContent(lp.overlay, file); ok {
+			src = content
+		}
+		f, err := parser.ParseFile(pkg.Fset, file, src, parser.ParseComments)
 		if err != nil {
 			pkg.Errors = append(pkg.Errors, lp.convertError(err)...)
 			return err
@@ -0,0 +1,77 @@
+package goanalysis
+
+import (
+	"context"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/packages"
+
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func TestExtractTextEdits(t *testing.T) {
+	fset := token.NewFileSet()
+	f := fset.AddFile("p.go", -1, 100)
+	pos := func(offset int) token.Pos {
+		return f.Pos(offset)
+	}
+
+	// suggested fixes are alternatives: only the first one is applied
+	diag := &analysis.Diagnostic{
+		SuggestedFixes: []analysis.SuggestedFix{
+			{
+				Message: "replace x",
+				TextEdits: []analysis.TextEdit{
+					{Pos: pos(10), End: pos(11), NewText: []byte("y")},
+					{Pos: pos(20), NewText: []byte("z")},
+				},
+			},
+			{
+				Message:   "remove x",
+				TextEdits: []analysis.TextEdit{{Pos: pos(10), End: pos(11)}},
+			},
+		},
+	}
+	assert.Equal(t, []result.TextEdit{
+		{Filename: "p.go", Offset: 10, EndOffset: 11, NewText: "y"},
+		{Filename: "p.go", Offset: 20, EndOffset: 20, NewText: "z"},
+	}, extractTextEdits(fset, diag))
+
+	assert.Empty(t, extractTextEdits(fset, &analysis.Diagnostic{}))
+
+	// partial fixes aren't applied
+	diag = &analysis.Diagnostic{
+		SuggestedFixes: []analysis.SuggestedFix{{
+			TextEdits: []analysis.TextEdit{
+				{Pos: pos(10), End: pos(11), NewText: []byte("y")},
+				{Pos: pos(30), End: pos(20)},
+			},
+		}},
+	}
+	assert.Empty(t, extractTextEdits(fset, diag))
+}
+
+func TestRunnerStopsOnDoneContext(t *testing.T) {
+	var ran bool
+	a := &analysis.Analyzer{
+		Name: "a",
+		Doc:  "a",
+		Run: func(*analysis.Pass) (interface{}, error) {
+			ran = true
+			return nil, nil
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	r := newRunner("test", logutils.NewStderrLog("test"), nil, nil, nil)
+	roots, err := r.analyze(ctx, []*packages.Package{{ID: "p", PkgPath: "p"}}, []*analysis.Analyzer{a})
+	assert.Equal(t, context.Canceled, err)
+	assert.Nil(t, roots)
+	assert.False(t, ran)
+}
@@ -5,6 +5,9 @@ import (
 	"context"
 	"fmt"
 	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"strings"
 
 	gofmtAPI "github.com/golangci/gofmt/gofmt"
@@ -13,6 +16,7 @@ import (
 	diffpkg "github.com/sourcegraph/go-diff/diff"
 	"golang.org/x/tools/imports"
 
+	"github.com/golangci/golangci-lint/pkg/fsutils"
 	"github.com/golangci/golangci-lint/pkg/lint/linter"
 	"github.com/golangci/golangci-lint/pkg/logutils"
 	"github.com/golangci/golangci-lint/pkg/result"
@@ -233,7 +237,7 @@ func (p *hunkChangesParser) parse(h *diffpkg.Hunk) []Change {
 	return p.ret
 }
 
-func (g Gofmt) extractIssuesFromPatch(patch string, log logutils.Log, lintCtx *linter.Context) ([]result.Issue, error) {
+func (g Gofmt) extractIssuesFromPatch(patch, filename string, log logutils.Log, lintCtx *linter.Context) ([]result.Issue, error) {
 	diffs, err := diffpkg.ParseMultiFileDiff([]byte(patch))
 	if err != nil {
 		return nil, errors.Wrap(err, "can't parse patch")
@@ -269,7 +273,7 @@ func (g Gofmt) extractIssuesFromPatch(patch string, log logutils.Log, lintCtx *l
 				i := result.Issue{
 					FromLinter: g.Name(),
 					Pos: token.Position{
-						Filename: d.NewName,
+						Filename: filename, // d.NewName is a temporary file for overlaid files
 						Line:     change.LineRange.From,
 					},
 					Text:        text,
@@ -291,14 +295,7 @@ func (g Gofmt) Run(ctx context.Context, lintCtx *linter.Context) ([]result.Issue
 	var issues []result.Issue
 
 	for _, f := range getAllFileNames(lintCtx) {
-		var diff []byte
-		var err error
-		if g.UseGoimports {
-			imports.LocalPrefix = lintCtx.Settings().Goimports.LocalPrefixes
-			diff, err = goimportsAPI.Run(f)
-		} else {
-			diff, err = gofmtAPI.Run(f, lintCtx.Settings().Gofmt.Simplify)
-		}
+		diff, err := g.runOnFile(f, lintCtx)
 		if err != nil { // TODO: skip
 			return nil, err
 		}
@@ -306,7 +303,7 @@ func (g Gofmt) Run(ctx context.Context, lintCtx *linter.Context) ([]result.Issue
 			continue
 		}
 
-		is, err := g.extractIssuesFromPatch(string(diff), lintCtx.Log, lintCtx)
+		is, err := g.extractIssuesFromPatch(string(diff), f, lintCtx.Log, lintCtx)
 		if err != nil {
 			return nil, fmt.Errorf("can't extract issues from gofmt diff output %q: %s", string(diff), err)
 		}
@@ -316,3 +313,40 @@ func (g Gofmt) Run(ctx context.Context, lintCtx *linter.Context) ([]result.Issue
 
 	return issues, nil
 }
+
+func (g Gofmt) runOnFile(f string, lintCtx *linter.Context) ([]byte, error) {
+	// gofmt and goimports read files only from disk: format the overlay contents
+	// (e.g. from stdin) in a temporary file to get issues and replacements for them
+	if src, ok := fsutils.OverlayContent(lintCtx.FileCache.Overlay(), f); ok {
+		tmpFile, err := writeTempGoFile(filepath.Dir(f), src)
+		if err != nil {
+			return nil, err
+		}
+		defer os.Remove(tmpFile)
+		f = tmpFile
+	}
+
+	if g.UseGoimports {
+		imports.LocalPrefix = lintCtx.Settings().Goimports.LocalPrefixes
+		return goimportsAPI.Run(f)
+	}
+
+	return gofmtAPI.Run(f, lintCtx.Settings().Gofmt.Simplify)
+}
+
+// writeTempGoFile writes the Go file into the directory: goimports finds the module
+// and the package of the file by its directory. Go tools ignore files starting with a dot.
+func writeTempGoFile(dir string, src []byte) (string, error) {
+	f, err := ioutil.TempFile(dir, ".golangci-lint-overlay-*.go")
+	if err != nil {
+		return "", errors.Wrap(err, "can't create temporary file")
+	}
+	defer f.Close()
+
+	if _, err = f.Write(src); err != nil {
+		os.Remove(f.Name())
+		return "", errors.Wrapf(err, "can't write temporary file %s", f.Name())
+	}
+
+	return f.Name(), nil
+}
@@ -2,10 +2,10 @@ package golinters
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"fmt"
 	"go/token"
-	"os"
 	"strings"
 	"unicode/utf8"
 
@@ -23,17 +23,17 @@ func (Lll) Desc() string {
 	return "Reports long lines"
 }
 
-func (lint Lll) getIssuesForFile(filename string, maxLineLen int, tabSpaces string) ([]result.Issue, error) {
+func (lint Lll) getIssuesForFile(lintCtx *linter.Context, filename string, maxLineLen int,
+	tabSpaces string) ([]result.Issue, error) {
 	var res []result.Issue
 
-	f, err := os.Open(filename)
+	fileBytes, err := lintCtx.FileCache.GetFileBytes(filename)
 	if err != nil {
-		return nil, fmt.Errorf("can't open file %s: %s", filename, err)
+		return nil, fmt.Errorf("can't read file %s: %s", filename, err)
 	}
-	defer f.Close()
 
 	lineNumber := 1
-	scanner := bufio.NewScanner(f)
+	scanner := bufio.NewScanner(bytes.NewReader(fileBytes))
 	for scanner.Scan() {
 		line := scanner.Text()
 		line = strings.Replace(line, "\t", tabSpaces, -1)
@@ -84,7 +84,7 @@ func (lint Lll) Run(ctx context.Context, lintCtx *linter.Context) ([]result.Issu
 	var res []result.Issue
 	spaces := strings.Repeat(" ", lintCtx.Settings().Lll.TabWidth)
 	for _, f := range getAllFileNames(lintCtx) {
-		issues, err := lint.getIssuesForFile(f, lintCtx.Settings().Lll.LineLength, spaces)
+		issues, err := lint.getIssuesForFile(lintCtx, f, lintCtx.Settings().Lll.LineLength, spaces)
 		if err != nil {
 			return nil, err
 		}
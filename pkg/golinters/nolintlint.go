@@ -0,0 +1,26 @@
+package golinters
+
+import (
+	"context"
+
+	"github.com/golangci/golangci-lint/pkg/lint/linter"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+const NolintlintName = "nolintlint"
+
+type Nolintlint struct{}
+
+func (Nolintlint) Name() string {
+	return NolintlintName
+}
+
+func (Nolintlint) Desc() string {
+	return "Reports unused, not specific and not explained //nolint directives"
+}
+
+func (Nolintlint) Run(ctx context.Context, lintCtx *linter.Context) ([]result.Issue, error) {
+	// nolint directives can be checked only after processing issues of all other linters:
+	// issues are reported by the nolint processor
+	return nil, nil
+}
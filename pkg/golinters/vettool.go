@@ -0,0 +1,179 @@
+package golinters
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"io"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/golangci/golangci-lint/pkg/lint/linter"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+// VetTool runs a vet-style analysis tool built with unitchecker.Main
+// by `go vet -vettool` and converts its JSON output into issues.
+type VetTool struct {
+	name, desc string
+	path       string
+}
+
+func NewVetTool(name, desc, path string) *VetTool {
+	return &VetTool{
+		name: name,
+		desc: desc,
+		path: path,
+	}
+}
+
+func (v VetTool) Name() string {
+	return v.name
+}
+
+func (v VetTool) Desc() string {
+	return v.desc
+}
+
+// vetToolDiagnostic is a diagnostic in the `go vet -json` output
+type vetToolDiagnostic struct {
+	Posn    string `json:"posn"`
+	Message string `json:"message"`
+}
+
+// vetToolError is an analyzer error in the `go vet -json` output
+type vetToolError struct {
+	Err string `json:"error"`
+}
+
+var vetToolPosnRe = regexp.MustCompile(`^(.+?):(\d+)(?::(\d+))?$`)
+
+func (v VetTool) Run(ctx context.Context, lintCtx *linter.Context) ([]result.Issue, error) {
+	var pkgPaths []string
+	seenPkgPaths := map[string]bool{}
+	for _, pkg := range lintCtx.Packages {
+		// external test packages are checked by go vet together with the package
+		pkgPath := strings.TrimSuffix(pkg.PkgPath, "_test")
+		if !seenPkgPaths[pkgPath] {
+			seenPkgPaths[pkgPath] = true
+			pkgPaths = append(pkgPaths, pkgPath)
+		}
+	}
+	if len(pkgPaths) == 0 {
+		return nil, nil
+	}
+	sort.Strings(pkgPaths)
+
+	var stderr bytes.Buffer
+	cmd := v.command(ctx, lintCtx, pkgPaths)
+	cmd.Stderr = &stderr
+	runErr := cmd.Run()
+
+	issues, err := v.parseOutput(stderr.Bytes(), lintCtx.Log)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse output of %s", v.path)
+	}
+	if runErr != nil && len(issues) == 0 {
+		return nil, fmt.Errorf("failed to run go %s: %s: %s",
+			strings.Join(cmd.Args[1:], " "), runErr, strings.TrimSpace(stderr.String()))
+	}
+
+	return issues, nil
+}
+
+// command returns the go vet command: packages are vetted in the module they are loaded from
+// for the build combination they are loaded for
+func (v VetTool) command(ctx context.Context, lintCtx *linter.Context, pkgPaths []string) *exec.Cmd {
+	args := []string{"vet", "-vettool=" + v.path, "-json"}
+	if tags := lintCtx.BuildTags(); len(tags) != 0 {
+		args = append(args, "-tags", strings.Join(tags, " "))
+	}
+	args = append(args, pkgPaths...)
+
+	cmd := exec.CommandContext(ctx, "go", args...)
+	cmd.Dir = lintCtx.Dir
+	cmd.Env = lintCtx.Build.Env()
+	return cmd
+}
+
+// parseOutput parses the output of `go vet -json`: it's a sequence of JSON
+// objects `{"pkg": {"analyzer": [diagnostics] or {"error": ...}}}` with
+// interleaved `# pkg` comment lines.
+func (v VetTool) parseOutput(out []byte, log logutils.Log) ([]result.Issue, error) {
+	var jsonLines [][]byte
+	for _, line := range bytes.Split(out, []byte("\n")) {
+		if !bytes.HasPrefix(line, []byte("#")) {
+			jsonLines = append(jsonLines, line)
+		}
+	}
+
+	var issues []result.Issue
+	dec := json.NewDecoder(bytes.NewReader(bytes.Join(jsonLines, []byte("\n"))))
+	for {
+		var tree map[string]map[string]json.RawMessage
+		if err := dec.Decode(&tree); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+
+		for pkgPath, pkgResults := range tree {
+			for analyzerName, analyzerResult := range pkgResults {
+				analyzerIssues, err := v.parseAnalyzerResult(analyzerName, analyzerResult)
+				if err != nil {
+					log.Warnf("%s: analyzer %s failed on package %s: %s", v.name, analyzerName, pkgPath, err)
+					continue
+				}
+				issues = append(issues, analyzerIssues...)
+			}
+		}
+	}
+
+	return issues, nil
+}
+
+func (v VetTool) parseAnalyzerResult(analyzerName string, data json.RawMessage) ([]result.Issue, error) {
+	var diags []vetToolDiagnostic
+	if err := json.Unmarshal(data, &diags); err != nil {
+		var analyzerErr vetToolError
+		if json.Unmarshal(data, &analyzerErr) == nil && analyzerErr.Err != "" {
+			return nil, errors.New(analyzerErr.Err)
+		}
+		return nil, err
+	}
+
+	issues := make([]result.Issue, 0, len(diags))
+	for _, diag := range diags {
+		issues = append(issues, result.Issue{
+			FromLinter: v.name,
+			Text:       fmt.Sprintf("%s: %s", analyzerName, diag.Message),
+			Pos:        parseVetToolPosn(diag.Posn),
+		})
+	}
+
+	return issues, nil
+}
+
+func parseVetToolPosn(posn string) token.Position {
+	m := vetToolPosnRe.FindStringSubmatch(posn)
+	if m == nil {
+		return token.Position{Filename: posn}
+	}
+
+	line, _ := strconv.Atoi(m[2])
+	col, _ := strconv.Atoi(m[3])
+	return token.Position{
+		Filename: m[1],
+		Line:     line,
+		Column:   col,
+	}
+}
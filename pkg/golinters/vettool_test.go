@@ -0,0 +1,82 @@
+package golinters
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/lint/linter"
+	"github.com/golangci/golangci-lint/pkg/logutils/mock_logutils"
+)
+
+func TestVetToolParseOutput(t *testing.T) {
+	const out = `# example.com/a
+{
+	"example.com/a": {
+		"nofoo": [
+			{
+				"posn": "/src/a/a.go:3:6",
+				"message": "don't name functions foo"
+			},
+			{
+				"posn": "/src/a/a_test.go:10",
+				"message": "don't name tests foo"
+			}
+		]
+	}
+}
+# example.com/b
+{
+	"example.com/b": {
+		"nofoo": {
+			"error": "analysis skipped due to errors in package"
+		}
+	}
+}
+`
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	log := mock_logutils.NewMockLog(ctrl)
+	log.EXPECT().Warnf("%s: analyzer %s failed on package %s: %s", "custom", "nofoo", "example.com/b",
+		gomock.Any())
+
+	issues, err := NewVetTool("custom", "", "").parseOutput([]byte(out), log)
+	assert.NoError(t, err)
+	assert.Len(t, issues, 2)
+
+	sort.Slice(issues, func(i, j int) bool {
+		return issues[i].FilePath() < issues[j].FilePath()
+	})
+
+	assert.Equal(t, "custom", issues[0].FromLinter)
+	assert.Equal(t, "nofoo: don't name functions foo", issues[0].Text)
+	assert.Equal(t, "/src/a/a.go", issues[0].FilePath())
+	assert.Equal(t, 3, issues[0].Line())
+	assert.Equal(t, 6, issues[0].Column())
+
+	assert.Equal(t, "nofoo: don't name tests foo", issues[1].Text)
+	assert.Equal(t, "/src/a/a_test.go", issues[1].FilePath())
+	assert.Equal(t, 10, issues[1].Line())
+	assert.Equal(t, 0, issues[1].Column())
+}
+
+func TestVetToolCommand(t *testing.T) {
+	cfg := config.NewDefault()
+	cfg.Run.BuildTags = []string{"tools"}
+	lintCtx := &linter.Context{
+		Dir:   "/src/mod",
+		Build: config.BuildCombination{Tags: []string{"integration"}, GOOS: "windows"},
+		Cfg:   cfg,
+	}
+
+	cmd := NewVetTool("custom", "", "/bin/vettool").command(context.Background(), lintCtx, []string{"example.com/a"})
+	assert.Equal(t, []string{"go", "vet", "-vettool=/bin/vettool", "-json", "-tags", "tools integration", "example.com/a"},
+		cmd.Args)
+	assert.Equal(t, "/src/mod", cmd.Dir)
+	assert.Contains(t, cmd.Env, "GOOS=windows")
+}
@@ -20,9 +20,11 @@ type File struct {
 }
 
 type Cache struct {
-	m   map[string]*File // map from absolute file path to file data
-	s   []*File
-	log logutils.Log
+	m       map[string]*File // map from absolute file path to file data
+	s       []*File
+	log     logutils.Log
+	overlay map[string][]byte // map from absolute file path to contents replacing the file
+	prev    *Cache            // previous cache to reuse parsed files from
 }
 
 func NewCache(log logutils.Log) *Cache {
@@ -83,6 +85,38 @@ func (c *Cache) prepareValidFiles() {
 	c.s = files
 }
 
+// Subset returns a cache containing only files of the given packages
+func (c Cache) Subset(pkgs []*packages.Package) *Cache {
+	ret := NewCache(c.log)
+	for _, pkg := range pkgs {
+		for _, filePath := range pkg.GoFiles {
+			filePath = c.normalizeFilename(filePath)
+			if f := c.m[filePath]; f != nil {
+				ret.m[filePath] = f
+			}
+		}
+	}
+
+	ret.prepareValidFiles()
+	return ret
+}
+
+// Merge returns a cache containing files of all caches: caches loaded
+// for different build combinations contain different files
+func Merge(log logutils.Log, caches ...*Cache) *Cache {
+	ret := NewCache(log)
+	for _, c := range caches {
+		for filePath, f := range c.m {
+			if ret.m[filePath] == nil {
+				ret.m[filePath] = f
+			}
+		}
+	}
+
+	ret.prepareValidFiles()
+	return ret
+}
+
 func LoadFromFilenames(log logutils.Log, filenames ...string) *Cache {
 	c := NewCache(log)
 
@@ -95,17 +129,51 @@ func LoadFromFilenames(log logutils.Log, filenames ...string) *Cache {
 	return c
 }
 
-func LoadFromPackages(pkgs []*packages.Package, log logutils.Log) (*Cache, error) {
+// LoadFromPackages loads ASTs of files of the packages: files from the overlay
+// are parsed from the overlay contents instead of disk. Files parsed by
+// the previous cache prev are reused if it's not nil.
+func LoadFromPackages(pkgs []*packages.Package, overlay map[string][]byte, prev *Cache,
+	log logutils.Log) (*Cache, error) {
 	c := NewCache(log)
+	c.overlay = overlay
+	c.prev = prev
 
 	for _, pkg := range pkgs {
 		c.loadFromPackage(pkg)
 	}
 
+	c.prev = nil // don't keep a chain of caches
 	c.prepareValidFiles()
 	return c, nil
 }
 
+// Forget removes the file from the cache: it's needed when the file was
+// changed and the cache is going to be reused by LoadFromPackages.
+func (c *Cache) Forget(filename string) {
+	filePath, err := fsutils.OverlayPath(filename) // don't warn about removed files as normalizeFilename does
+	if err != nil || c.m[filePath] == nil {
+		return
+	}
+
+	delete(c.m, filePath)
+	c.prepareValidFiles()
+}
+
+// reusableFile returns the file parsed by the previous cache if its contents weren't replaced by overlays
+func (c *Cache) reusableFile(filePath string) *File {
+	if c.prev == nil {
+		return nil
+	}
+	if _, ok := c.overlay[filePath]; ok {
+		return nil
+	}
+	if _, ok := c.prev.overlay[filePath]; ok {
+		return nil
+	}
+
+	return c.prev.m[filePath]
+}
+
 func (c *Cache) extractFilenamesForAstFile(fset *token.FileSet, f *ast.File) []string {
 	var ret []string
 
@@ -135,7 +203,13 @@ func (c *Cache) loadFromPackage(pkg *packages.Package) {
 	fset := token.NewFileSet() // can't use pkg.Fset: it will overwrite offsets by preprocessed files
 	for _, filePath := range pkg.GoFiles {
 		filePath = c.normalizeFilename(filePath)
-		if c.m[filePath] == nil {
+		if c.m[filePath] != nil {
+			continue
+		}
+
+		if f := c.reusableFile(filePath); f != nil {
+			c.m[filePath] = f
+		} else {
 			c.parseFile(filePath, fset)
 		}
 	}
@@ -148,8 +222,13 @@ func (c *Cache) parseFile(filePath string, fset *token.FileSet) {
 
 	filePath = c.normalizeFilename(filePath)
 
+	var src interface{}
+	if content, ok := c.overlay[filePath]; ok {
+		src = content
+	}
+
 	// comments needed by e.g. golint
-	f, err := parser.ParseFile(fset, filePath, nil, parser.ParseComments)
+	f, err := parser.ParseFile(fset, filePath, src, parser.ParseComments)
 	c.m[filePath] = &File{
 		F:    f,
 		Fset: fset,
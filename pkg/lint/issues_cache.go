@@ -0,0 +1,146 @@
+package lint
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"path/filepath"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/golangci/golangci-lint/internal/pkgcache"
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/fsutils"
+	"github.com/golangci/golangci-lint/pkg/lint/linter"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+// runLinterWithCache runs the linter only on packages without cached issues.
+// Cached issues are invalidated when a package, linters settings or
+// golangci-lint version change.
+func (r *Runner) runLinterWithCache(ctx context.Context, lintCtx *linter.Context,
+	lc *linter.Config) ([]result.Issue, error) {
+	if !lc.CanCacheIssues || lintCtx.PkgCache == nil {
+		return lc.Linter.Run(ctx, lintCtx)
+	}
+
+	cacheKey := getIssuesCacheKey(lintCtx, lc)
+	issues, pkgsToLint := loadIssuesFromCache(lintCtx, cacheKey)
+	lintCtx.Log.Infof("Loaded issues of %d/%d packages from cache",
+		len(lintCtx.Packages)-len(pkgsToLint), len(lintCtx.Packages))
+	if len(pkgsToLint) == 0 {
+		return issues, nil
+	}
+
+	pkgsLintCtx := lintCtx
+	if len(pkgsToLint) != len(lintCtx.Packages) {
+		pkgsLintCtx = getLintContextForPackages(lintCtx, pkgsToLint)
+	}
+
+	newIssues, err := lc.Linter.Run(ctx, pkgsLintCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	saveIssuesToCache(lintCtx, pkgsToLint, newIssues, cacheKey)
+	return append(issues, newIssues...), nil
+}
+
+func getIssuesCacheKey(lintCtx *linter.Context, lc *linter.Config) string {
+	// all settings are hashed because some linters use settings of other linters,
+	// settings not affecting issues of cached linters aren't hashed to not invalidate the cache
+	settings := lintCtx.Cfg.LintersSettings
+	settings.Timeouts = nil
+	settings.Custom = nil   // custom linters don't cache issues
+	settings.External = nil // external linters don't cache issues
+	settings.Nolintlint = config.NolintlintSettings{}
+
+	settingsHash := sha256.Sum256([]byte(fmt.Sprintf("%#v", settings)))
+	return fmt.Sprintf("lint/result:%s:%x", lc.Name(), settingsHash)
+}
+
+func loadIssuesFromCache(lintCtx *linter.Context, cacheKey string) (issues []result.Issue, pkgsToLint []*packages.Package) {
+	for _, pkg := range lintCtx.Packages {
+		var pkgIssues []result.Issue
+		if err := lintCtx.PkgCache.Get(pkg, cacheKey, &pkgIssues); err != nil {
+			if err != pkgcache.ErrMissing {
+				lintCtx.Log.Infof("Failed to load cached issues for package %s: %s", pkg.Name, err)
+			}
+			pkgsToLint = append(pkgsToLint, pkg)
+			continue
+		}
+
+		issues = append(issues, pkgIssues...)
+	}
+
+	return issues, pkgsToLint
+}
+
+func saveIssuesToCache(lintCtx *linter.Context, pkgs []*packages.Package, issues []result.Issue, cacheKey string) {
+	fileToPkg := map[string]*packages.Package{}
+	for _, pkg := range pkgs {
+		for _, files := range [][]string{pkg.GoFiles, pkg.CompiledGoFiles} {
+			for _, f := range files {
+				fileToPkg[normalizeIssuesCacheFilePath(f)] = pkg
+			}
+		}
+	}
+
+	pkgIssues := map[*packages.Package][]result.Issue{}
+	for _, i := range issues {
+		pkg := fileToPkg[normalizeIssuesCacheFilePath(i.FilePath())]
+		if pkg == nil {
+			// don't save issues at all: otherwise the issue will be lost on the next run
+			lintCtx.Log.Infof("Don't cache issues: can't find package for issue in file %s", i.FilePath())
+			return
+		}
+		pkgIssues[pkg] = append(pkgIssues[pkg], i)
+	}
+
+	for _, pkg := range pkgs {
+		// save empty issues too: it marks the package as already linted
+		issuesToSave := pkgIssues[pkg]
+		if issuesToSave == nil {
+			issuesToSave = []result.Issue{}
+		}
+
+		if err := lintCtx.PkgCache.Put(pkg, cacheKey, issuesToSave); err != nil {
+			lintCtx.Log.Infof("Failed to save issues for package %s to cache: %s", pkg.Name, err)
+		}
+	}
+}
+
+func normalizeIssuesCacheFilePath(path string) string {
+	if absPath, err := filepath.Abs(path); err == nil {
+		path = absPath
+	}
+
+	if evalPath, err := fsutils.EvalSymlinks(path); err == nil {
+		path = evalPath
+	}
+
+	return path
+}
+
+// getLintContextForPackages returns lint context with only the given packages.
+// It's valid only for linters which can cache issues: they don't use
+// loader program or SSA.
+func getLintContextForPackages(lintCtx *linter.Context, pkgs []*packages.Package) *linter.Context {
+	pkgPaths := map[string]bool{}
+	for _, pkg := range pkgs {
+		pkgPaths[pkg.PkgPath] = true
+	}
+
+	var originalPkgs []*packages.Package
+	for _, pkg := range lintCtx.OriginalPackages {
+		if pkgPaths[pkg.PkgPath] {
+			originalPkgs = append(originalPkgs, pkg)
+		}
+	}
+
+	ret := *lintCtx
+	ret.Packages = pkgs
+	ret.OriginalPackages = originalPkgs
+	ret.ASTCache = lintCtx.ASTCache.Subset(pkgs)
+	return &ret
+}
@@ -0,0 +1,152 @@
+package lint
+
+import (
+	"context"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+
+	"github.com/golangci/golangci-lint/internal/pkgcache"
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/lint/astcache"
+	"github.com/golangci/golangci-lint/pkg/lint/linter"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+	"github.com/golangci/golangci-lint/pkg/timeutils"
+)
+
+// cachedTestLinter reports an issue in the first file of every package and records linted packages
+type cachedTestLinter struct {
+	lintedPkgs []string
+	extraFile  string // file of an issue outside of linted packages
+}
+
+func (l *cachedTestLinter) Name() string { return "cachedtest" }
+func (l *cachedTestLinter) Desc() string { return "Linter for issues cache tests" }
+
+func (l *cachedTestLinter) Run(_ context.Context, lintCtx *linter.Context) ([]result.Issue, error) {
+	var issues []result.Issue
+	for _, pkg := range lintCtx.Packages {
+		l.lintedPkgs = append(l.lintedPkgs, pkg.Name)
+		issues = append(issues, result.Issue{
+			FromLinter: l.Name(),
+			Text:       "issue in " + pkg.Name,
+			Pos:        token.Position{Filename: pkg.GoFiles[0], Line: 1},
+		})
+	}
+	if l.extraFile != "" {
+		issues = append(issues, result.Issue{
+			FromLinter: l.Name(),
+			Text:       "issue outside of packages",
+			Pos:        token.Position{Filename: l.extraFile, Line: 1},
+		})
+	}
+	return issues, nil
+}
+
+func issueTexts(issues []result.Issue) []string {
+	var ret []string
+	for _, i := range issues {
+		ret = append(ret, i.Text)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+func TestIssuesCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "golangci_lint_issues_cache_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	require.NoError(t, os.Setenv("GOLANGCI_LINT_CACHE", filepath.Join(dir, "cache")))
+
+	log := logutils.NewStderrLog("issues_cache_test")
+	pkgCache, err := pkgcache.NewCache(timeutils.NewStopwatch("pkgcache", log), log)
+	require.NoError(t, err)
+
+	var pkgs []*packages.Package
+	for _, name := range []string{"a", "b"} {
+		file := filepath.Join(dir, name, name+".go")
+		require.NoError(t, os.MkdirAll(filepath.Dir(file), os.ModePerm))
+		require.NoError(t, ioutil.WriteFile(file, []byte("package "+name+"\n"), os.ModePerm))
+		pkgs = append(pkgs, &packages.Package{
+			Name:            name,
+			PkgPath:         filepath.ToSlash(filepath.Join(dir, name)), // unique for every test run
+			GoFiles:         []string{file},
+			CompiledGoFiles: []string{file},
+		})
+	}
+
+	cfg := config.NewDefault()
+	lintCtx := &linter.Context{
+		Packages:         pkgs,
+		OriginalPackages: pkgs,
+		Cfg:              cfg,
+		ASTCache:         astcache.LoadFromFilenames(log, pkgs[0].GoFiles[0], pkgs[1].GoFiles[0]),
+		Log:              log,
+		PkgCache:         pkgCache,
+	}
+
+	l := &cachedTestLinter{}
+	lc := linter.NewConfig(l).WithIssuesCache()
+	r := &Runner{Log: log}
+	run := func() []string {
+		l.lintedPkgs = nil
+		issues, err := r.runLinterWithCache(context.Background(), lintCtx, lc)
+		require.NoError(t, err)
+		return issueTexts(issues)
+	}
+	allIssues := []string{"issue in a", "issue in b"}
+
+	assert.Equal(t, allIssues, run())
+	assert.Equal(t, []string{"a", "b"}, l.lintedPkgs)
+
+	// cache hit
+	assert.Equal(t, allIssues, run())
+	assert.Empty(t, l.lintedPkgs)
+
+	// settings not affecting issues don't invalidate the cache
+	cfg.LintersSettings.Timeouts = map[string]time.Duration{"cachedtest": time.Minute}
+	assert.Equal(t, allIssues, run())
+	assert.Empty(t, l.lintedPkgs)
+
+	// changed settings invalidate the cache
+	cfg.LintersSettings.Lll.LineLength++
+	assert.Equal(t, allIssues, run())
+	assert.Equal(t, []string{"a", "b"}, l.lintedPkgs)
+
+	// only the changed package is linted
+	require.NoError(t, ioutil.WriteFile(pkgs[1].GoFiles[0], []byte("package b\n\nvar B int\n"), os.ModePerm))
+	pkgCache.Reset(pkgs[1].GoFiles)
+	assert.Equal(t, allIssues, run())
+	assert.Equal(t, []string{"b"}, l.lintedPkgs)
+
+	// issues aren't cached if a package of an issue isn't found: otherwise it's lost on the next run
+	cfg.LintersSettings.Lll.LineLength++
+	l.extraFile = filepath.Join(dir, "other.go")
+	assert.Equal(t, append(allIssues, "issue outside of packages"), run())
+	assert.Equal(t, []string{"a", "b"}, l.lintedPkgs)
+	assert.Equal(t, append(allIssues, "issue outside of packages"), run())
+	assert.Equal(t, []string{"a", "b"}, l.lintedPkgs)
+}
+
+func TestIssuesCacheKey(t *testing.T) {
+	cfg := config.NewDefault()
+	lintCtx := &linter.Context{Cfg: cfg}
+	lc := linter.NewConfig(&cachedTestLinter{})
+	key := getIssuesCacheKey(lintCtx, lc)
+
+	cfg.LintersSettings.Timeouts = map[string]time.Duration{"govet": time.Second}
+	cfg.LintersSettings.External = map[string]config.ExternalLinterSettings{"ext": {Command: []string{"ext"}}}
+	assert.Equal(t, key, getIssuesCacheKey(lintCtx, lc))
+
+	cfg.LintersSettings.Godox.Keywords = []string{"FIXME"}
+	assert.NotEqual(t, key, getIssuesCacheKey(lintCtx, lc))
+}
@@ -29,6 +29,8 @@ type Config struct {
 	ParentLinterName string // used only for megacheck's children now
 	CanAutoFix       bool
 	IsSlow           bool
+	CanCacheIssues   bool // issues depend only on files of a package: they can be cached per package
+	IsContextAware   bool // the linter stops soon after its context is done: only such linters can have timeouts
 }
 
 func (lc *Config) ConsiderSlow() *Config {
@@ -99,6 +101,22 @@ func (lc *Config) WithAutoFix() *Config {
 	return lc
 }
 
+func (lc *Config) WithIssuesCache() *Config {
+	lc.CanCacheIssues = true
+	return lc
+}
+
+func (lc *Config) WithContextAware() *Config {
+	lc.IsContextAware = true
+	return lc
+}
+
+// UsesWholeProgram returns true for linters using the loader program or SSA of all loaded packages:
+// they can't lint a subset of packages
+func (lc *Config) UsesWholeProgram() bool {
+	return lc.LoadMode&packages.NeedTypes != 0 && !lc.CanCacheIssues
+}
+
 func (lc *Config) GetSpeed() int {
 	return lc.Speed
 }
@@ -31,6 +31,11 @@ type Context struct {
 
 	SSAProgram *ssa.Program // for unparam and interfacer but not for megacheck (it change it)
 
+	// Dir is the directory packages are loaded in: the module directory or empty for the working directory
+	Dir string
+	// Build is the combination of run.build-matrix packages are loaded for, empty if it isn't used
+	Build config.BuildCombination
+
 	Cfg       *config.Config
 	ASTCache  *astcache.Cache
 	FileCache *fsutils.FileCache
@@ -44,3 +49,8 @@ type Context struct {
 func (c *Context) Settings() *config.LintersSettings {
 	return &c.Cfg.LintersSettings
 }
+
+// BuildTags returns build tags packages are loaded with: run.build-tags and tags of the build combination
+func (c *Context) BuildTags() []string {
+	return c.Cfg.Run.BuildTagsFor(c.Build)
+}
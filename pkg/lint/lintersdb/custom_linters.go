@@ -0,0 +1,229 @@
+package lintersdb
+
+import (
+	"fmt"
+	"plugin"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/golinters"
+	"github.com/golangci/golangci-lint/pkg/golinters/goanalysis"
+	"github.com/golangci/golangci-lint/pkg/lint/linter"
+)
+
+// pluginAnalyzersSymbol is a name of the variable with analyzers exported by a custom linter plugin
+const pluginAnalyzersSymbol = "Analyzers"
+
+// LoadCustomLinters loads linters from the `linters-settings.custom` and
+// `linters-settings.external` config sections. Custom linters are enabled by default.
+func (m *Manager) LoadCustomLinters() error {
+	if m.cfg == nil {
+		return nil
+	}
+
+	var customNames []string
+	for name := range m.cfg.LintersSettings.Custom {
+		customNames = append(customNames, name)
+	}
+	sort.Strings(customNames)
+
+	for _, name := range customNames {
+		lc, err := m.loadCustomLinterConfig(name, m.cfg.LintersSettings.Custom[name])
+		if err != nil {
+			return errors.Wrapf(err, "failed to load custom linter %q", name)
+		}
+
+		if err = m.addCustomLinter(lc); err != nil {
+			return err
+		}
+	}
+
+	var externalNames []string
+	for name := range m.cfg.LintersSettings.External {
+		externalNames = append(externalNames, name)
+	}
+	sort.Strings(externalNames)
+
+	for _, name := range externalNames {
+		lc, err := m.loadExternalLinterConfig(name, m.cfg.LintersSettings.External[name])
+		if err != nil {
+			return errors.Wrapf(err, "failed to load external linter %q", name)
+		}
+
+		if err = m.addCustomLinter(lc); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *Manager) addCustomLinter(lc *linter.Config) error {
+	if m.nameToLC[lc.Name()] != nil {
+		return fmt.Errorf("custom linter %q has the same name as another linter", lc.Name())
+	}
+
+	lc.EnabledByDefault = true
+	m.customLinters = append(m.customLinters, lc)
+	m.nameToLC[lc.Name()] = lc
+	return nil
+}
+
+func (m Manager) validateCustomLinterPresets(presets []string) error {
+	allPresets := m.allPresetsSet()
+	for _, p := range presets {
+		if !allPresets[p] {
+			return fmt.Errorf("no such preset %q: only next presets exist: (%v)", p, m.AllPresets())
+		}
+	}
+
+	return nil
+}
+
+func (m Manager) loadCustomLinterConfig(name string, settings config.CustomLinterSettings) (*linter.Config, error) {
+	if err := m.validateCustomLinterPresets(settings.Presets); err != nil {
+		return nil, err
+	}
+
+	desc := settings.Description
+	if desc == "" {
+		desc = "Custom linter " + name
+	}
+
+	var lc *linter.Config
+	switch {
+	case settings.Path != "" && settings.VetTool != "":
+		return nil, errors.New("only one of path and vettool can be set")
+	case settings.Path != "":
+		analyzers, err := loadPluginAnalyzers(settings.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		lc = linter.NewConfig(goanalysis.NewLinter(name, desc, analyzers, settings.Settings)).
+			WithLoadForGoAnalysis().
+			WithContextAware()
+	case settings.VetTool != "":
+		if len(settings.Settings) != 0 {
+			return nil, errors.New("settings are supported only for plugins: pass flags to vettool by building it")
+		}
+
+		lc = linter.NewConfig(golinters.NewVetTool(name, desc, settings.VetTool)).
+			WithLoadFiles().
+			WithContextAware().
+			ConsiderSlow()
+	default:
+		return nil, errors.New("path or vettool must be set")
+	}
+
+	return lc.
+		WithPresets(settings.Presets...).
+		WithSpeed(1).
+		WithURL(settings.OriginalURL), nil
+}
+
+func (m Manager) loadExternalLinterConfig(name string, settings config.ExternalLinterSettings) (*linter.Config, error) {
+	if err := m.validateCustomLinterPresets(settings.Presets); err != nil {
+		return nil, err
+	}
+
+	if len(settings.Command) == 0 {
+		return nil, errors.New("command must be set")
+	}
+
+	mode := settings.Mode
+	switch mode {
+	case "":
+		mode = golinters.ExternalModeFiles
+	case golinters.ExternalModeFiles, golinters.ExternalModePackage:
+	default:
+		return nil, fmt.Errorf("invalid mode %q: only %q and %q are supported",
+			mode, golinters.ExternalModeFiles, golinters.ExternalModePackage)
+	}
+
+	var patterns []*regexp.Regexp
+	for _, p := range settings.Pattern {
+		re, err := regexp.Compile(p)
+		if err == nil {
+			err = checkPatternGroups(re)
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid pattern %q", p)
+		}
+		patterns = append(patterns, re)
+	}
+	for _, ef := range settings.ErrorFormat {
+		re, err := golinters.ErrorFormatToRegexp(ef)
+		if err != nil {
+			return nil, err
+		}
+		if err = checkPatternGroups(re); err != nil {
+			return nil, errors.Wrapf(err, "invalid errorformat %q", ef)
+		}
+		patterns = append(patterns, re)
+	}
+	if len(patterns) == 0 {
+		return nil, errors.New("pattern or errorformat must be set")
+	}
+
+	desc := settings.Description
+	if desc == "" {
+		desc = "External linter " + name
+	}
+
+	return linter.NewConfig(golinters.NewExternal(name, desc, settings.Command, mode, patterns)).
+		WithLoadFiles().
+		WithContextAware().
+		WithPresets(settings.Presets...).
+		WithSpeed(1).
+		WithURL(settings.OriginalURL), nil
+}
+
+// requiredPatternGroups are named groups of patterns of external linters: lines without them aren't issues
+var requiredPatternGroups = []string{"file", "line", "message"}
+
+func checkPatternGroups(re *regexp.Regexp) error {
+	names := map[string]bool{}
+	for _, name := range re.SubexpNames() {
+		names[name] = true
+	}
+
+	var missing []string
+	for _, name := range requiredPatternGroups {
+		if !names[name] {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) != 0 {
+		return fmt.Errorf("no named groups %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+func loadPluginAnalyzers(path string) ([]*analysis.Analyzer, error) {
+	plug, err := plugin.Open(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open plugin %s", path)
+	}
+
+	sym, err := plug.Lookup(pluginAnalyzersSymbol)
+	if err != nil {
+		return nil, errors.Wrapf(err, "plugin %s doesn't export %s", path, pluginAnalyzersSymbol)
+	}
+
+	analyzers, ok := sym.(*[]*analysis.Analyzer)
+	if !ok {
+		return nil, fmt.Errorf("plugin %s exports %s of type %T, expected []*analysis.Analyzer",
+			path, pluginAnalyzersSymbol, sym)
+	}
+	if len(*analyzers) == 0 {
+		return nil, fmt.Errorf("plugin %s exports no analyzers", path)
+	}
+
+	return *analyzers, nil
+}
@@ -0,0 +1,30 @@
+package lintersdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+)
+
+func TestLoadExternalLinterConfigPatterns(t *testing.T) {
+	m := NewManager(nil)
+	load := func(pattern, errorFormat []string) error {
+		_, err := m.loadExternalLinterConfig("ext", config.ExternalLinterSettings{
+			Command:     []string{"ext"},
+			Pattern:     pattern,
+			ErrorFormat: errorFormat,
+		})
+		return err
+	}
+
+	assert.NoError(t, load([]string{`^(?P<file>[^:]+):(?P<line>\d+): (?P<message>.+)$`}, nil))
+	assert.NoError(t, load(nil, []string{"%f:%l:%c: %m"}))
+
+	err := load([]string{`^([^:]+):(?P<line>\d+): (?P<msg>.+)$`}, nil)
+	assert.EqualError(t, err, `invalid pattern "^([^:]+):(?P<line>\\d+): (?P<msg>.+)$": no named groups file, message`)
+
+	err = load(nil, []string{"%f: %m"})
+	assert.EqualError(t, err, `invalid errorformat "%f: %m": no named groups line`)
+}
@@ -121,6 +121,16 @@ func (es EnabledSet) optimizeLintersSet(linters map[string]*linter.Config) {
 	}
 }
 
+// GetEnabledLintersMap returns enabled linters by their names: linters
+// aren't optimized into metalinters.
+func (es EnabledSet) GetEnabledLintersMap() (map[string]*linter.Config, error) {
+	if err := es.v.validateEnabledDisabledLintersConfig(&es.cfg.Linters); err != nil {
+		return nil, err
+	}
+
+	return es.build(&es.cfg.Linters, es.m.GetAllEnabledByDefaultLinters()), nil
+}
+
 func (es EnabledSet) Get(optimize bool) ([]*linter.Config, error) {
 	if err := es.v.validateEnabledDisabledLintersConfig(&es.cfg.Linters); err != nil {
 		return nil, err
@@ -142,12 +152,20 @@ func (es EnabledSet) Get(optimize bool) ([]*linter.Config, error) {
 }
 
 func (es EnabledSet) combineGoAnalysisLinters(linters map[string]*linter.Config) {
+	// linters with timeouts run separately: a timeout stops only its linter
+	withTimeout := map[string]bool{}
+	for name := range es.cfg.LintersSettings.Timeouts {
+		if lc := es.m.GetLinterConfig(name); lc != nil {
+			withTimeout[lc.Name()] = true
+		}
+	}
+
 	var goanalysisLinters []*goanalysis.Linter
 	goanalysisPresets := map[string]bool{}
 	analyzerToLinterName := map[*analysis.Analyzer]string{}
 	for _, linter := range linters {
 		lnt, ok := linter.Linter.(goanalysis.SupportedLinter)
-		if !ok {
+		if !ok || withTimeout[linter.Name()] {
 			continue
 		}
 
@@ -191,7 +209,7 @@ func (es EnabledSet) combineGoAnalysisLinters(linters map[string]*linter.Config)
 		OriginalURL:      "",
 		ParentLinterName: "",
 	}
-	mlConfig = mlConfig.WithLoadForGoAnalysis()
+	mlConfig = mlConfig.WithLoadForGoAnalysis().WithContextAware()
 
 	linters[ml.Name()] = mlConfig
 	es.debugf("Combined %d go/analysis linters into one metalinter", len(goanalysisLinters))
@@ -3,6 +3,7 @@ package lintersdb
 import (
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/golangci/golangci-lint/pkg/golinters"
 
@@ -118,3 +119,24 @@ func TestGetEnabledLintersSet(t *testing.T) {
 		})
 	}
 }
+
+func TestCombineGoAnalysisLintersWithTimeouts(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.LintersSettings.Timeouts = map[string]time.Duration{"vet": time.Minute}
+
+	m := NewManager(cfg)
+	es := NewEnabledSet(m, NewValidator(m), nil, cfg)
+	linters := map[string]*linter.Config{}
+	for _, name := range []string{"govet", "bodyclose", "staticcheck"} {
+		linters[name] = m.GetLinterConfig(name)
+	}
+	es.combineGoAnalysisLinters(linters)
+
+	var names []string
+	for name, lc := range linters {
+		names = append(names, name)
+		assert.True(t, lc.IsContextAware, name)
+	}
+	sort.Strings(names)
+	assert.Equal(t, []string{"goanalysis_metalinter", "govet"}, names)
+}
@@ -10,8 +10,9 @@ import (
 )
 
 type Manager struct {
-	nameToLC map[string]*linter.Config
-	cfg      *config.Config
+	nameToLC      map[string]*linter.Config
+	cfg           *config.Config
+	customLinters []*linter.Config
 }
 
 func NewManager(cfg *config.Config) *Manager {
@@ -85,12 +86,15 @@ func (m Manager) GetAllSupportedLinterConfigs() []*linter.Config {
 	}
 	lcs := []*linter.Config{
 		linter.NewConfig(golinters.NewGovet(govetCfg)).
+			WithContextAware().
 			WithLoadForGoAnalysis().
 			WithPresets(linter.PresetBugs).
 			WithSpeed(4).
+			WithAutoFix().
 			WithAlternativeNames("vet", "vetshadow").
 			WithURL("https://golang.org/cmd/vet/"),
 		linter.NewConfig(golinters.NewBodyclose()).
+			WithContextAware().
 			WithLoadForGoAnalysis().
 			WithPresets(linter.PresetPerformance, linter.PresetBugs).
 			WithSpeed(4).
@@ -106,21 +110,25 @@ func (m Manager) GetAllSupportedLinterConfigs() []*linter.Config {
 			WithURL("https://github.com/golang/lint"),
 
 		linter.NewConfig(golinters.NewStaticcheck()).
+			WithContextAware().
 			WithLoadForGoAnalysis().
 			WithPresets(linter.PresetBugs).
 			WithSpeed(2).
 			WithURL("https://staticcheck.io/"),
 		linter.NewConfig(golinters.NewUnused()).
+			WithContextAware().
 			WithLoadDepsTypeInfo().
 			WithPresets(linter.PresetUnused).
 			WithSpeed(5).
 			WithURL("https://github.com/dominikh/go-tools/tree/master/cmd/unused"),
 		linter.NewConfig(golinters.NewGosimple()).
+			WithContextAware().
 			WithLoadForGoAnalysis().
 			WithPresets(linter.PresetStyle).
 			WithSpeed(5).
 			WithURL("https://github.com/dominikh/go-tools/tree/master/cmd/gosimple"),
 		linter.NewConfig(golinters.NewStylecheck()).
+			WithContextAware().
 			WithLoadForGoAnalysis().
 			WithPresets(linter.PresetStyle).
 			WithSpeed(5).
@@ -156,6 +164,7 @@ func (m Manager) GetAllSupportedLinterConfigs() []*linter.Config {
 		linter.NewConfig(golinters.Ineffassign{}).
 			WithPresets(linter.PresetUnused).
 			WithSpeed(9).
+			WithIssuesCache().
 			WithURL("https://github.com/gordonklaus/ineffassign"),
 		linter.NewConfig(golinters.Dupl{}).
 			WithPresets(linter.PresetStyle).
@@ -164,6 +173,7 @@ func (m Manager) GetAllSupportedLinterConfigs() []*linter.Config {
 		linter.NewConfig(golinters.Goconst{}).
 			WithPresets(linter.PresetStyle).
 			WithSpeed(9).
+			WithIssuesCache().
 			WithURL("https://github.com/jgautheron/goconst"),
 		linter.NewConfig(golinters.Deadcode{}).
 			WithLoadTypeInfo().
@@ -173,6 +183,7 @@ func (m Manager) GetAllSupportedLinterConfigs() []*linter.Config {
 		linter.NewConfig(golinters.Gocyclo{}).
 			WithPresets(linter.PresetComplexity).
 			WithSpeed(8).
+			WithIssuesCache().
 			WithURL("https://github.com/alecthomas/gocyclo"),
 		linter.NewConfig(golinters.TypeCheck{}).
 			WithLoadTypeInfo().
@@ -184,6 +195,7 @@ func (m Manager) GetAllSupportedLinterConfigs() []*linter.Config {
 			WithPresets(linter.PresetFormatting).
 			WithSpeed(7).
 			WithAutoFix().
+			WithIssuesCache().
 			WithURL("https://golang.org/cmd/gofmt/"),
 		linter.NewConfig(golinters.Gofmt{UseGoimports: true}).
 			WithPresets(linter.PresetFormatting).
@@ -204,10 +216,12 @@ func (m Manager) GetAllSupportedLinterConfigs() []*linter.Config {
 			WithPresets(linter.PresetStyle).
 			WithSpeed(7).
 			WithAutoFix().
+			WithIssuesCache().
 			WithURL("https://github.com/client9/misspell"),
 		linter.NewConfig(golinters.Lll{}).
 			WithPresets(linter.PresetStyle).
 			WithSpeed(10).
+			WithIssuesCache().
 			WithURL("https://github.com/walle/lll"),
 		linter.NewConfig(golinters.Unparam{}).
 			WithPresets(linter.PresetUnused).
@@ -218,18 +232,22 @@ func (m Manager) GetAllSupportedLinterConfigs() []*linter.Config {
 		linter.NewConfig(golinters.Dogsled{}).
 			WithPresets(linter.PresetStyle).
 			WithSpeed(10).
+			WithIssuesCache().
 			WithURL("https://github.com/alexkohler/dogsled"),
 		linter.NewConfig(golinters.Nakedret{}).
 			WithPresets(linter.PresetComplexity).
 			WithSpeed(10).
+			WithIssuesCache().
 			WithURL("https://github.com/alexkohler/nakedret"),
 		linter.NewConfig(golinters.Prealloc{}).
 			WithPresets(linter.PresetPerformance).
 			WithSpeed(8).
+			WithIssuesCache().
 			WithURL("https://github.com/alexkohler/prealloc"),
 		linter.NewConfig(golinters.Scopelint{}).
 			WithPresets(linter.PresetBugs).
 			WithSpeed(8).
+			WithIssuesCache().
 			WithURL("https://github.com/kyoh86/scopelint"),
 		linter.NewConfig(golinters.Gocritic{}).
 			WithPresets(linter.PresetStyle).
@@ -239,24 +257,32 @@ func (m Manager) GetAllSupportedLinterConfigs() []*linter.Config {
 		linter.NewConfig(golinters.Gochecknoinits{}).
 			WithPresets(linter.PresetStyle).
 			WithSpeed(10).
+			WithIssuesCache().
 			WithURL("https://github.com/leighmcculloch/gochecknoinits"),
 		linter.NewConfig(golinters.Gochecknoglobals{}).
 			WithPresets(linter.PresetStyle).
 			WithSpeed(10).
+			WithIssuesCache().
 			WithURL("https://github.com/leighmcculloch/gochecknoglobals"),
 		linter.NewConfig(golinters.Godox{}).
 			WithPresets(linter.PresetStyle).
 			WithSpeed(10).
+			WithIssuesCache().
 			WithURL("https://github.com/matoous/godox"),
 		linter.NewConfig(golinters.Funlen{}).
 			WithPresets(linter.PresetStyle).
 			WithSpeed(10).
+			WithIssuesCache().
 			WithURL("https://github.com/ultraware/funlen"),
 		linter.NewConfig(golinters.Whitespace{}).
 			WithPresets(linter.PresetStyle).
 			WithSpeed(10).
 			WithAutoFix().
+			WithIssuesCache().
 			WithURL("https://github.com/ultraware/whitespace"),
+		linter.NewConfig(golinters.Nolintlint{}).
+			WithPresets(linter.PresetStyle).
+			WithSpeed(10),
 	}
 
 	isLocalRun := os.Getenv("GOLANGCI_COM_RUN") == ""
@@ -274,9 +300,10 @@ func (m Manager) GetAllSupportedLinterConfigs() []*linter.Config {
 		// don't typecheck for golangci.com: too many troubles
 		golinters.TypeCheck{}.Name(): isLocalRun,
 	}
-	return enableLinterConfigs(lcs, func(lc *linter.Config) bool {
+	lcs = enableLinterConfigs(lcs, func(lc *linter.Config) bool {
 		return enabledByDefault[lc.Name()]
 	})
+	return append(lcs, m.customLinters...)
 }
 
 func (m Manager) GetAllEnabledByDefaultLinters() []*linter.Config {
@@ -42,6 +42,7 @@ type ContextLoader struct {
 	fileCache   *fsutils.FileCache
 	pkgCache    *pkgcache.Cache
 	loadGuard   *load.Guard
+	astCache    *astcache.Cache // the last loaded cache: it's reused by the next load
 }
 
 func NewContextLoader(cfg *config.Config, log logutils.Log, goenv *goutil.Env,
@@ -59,17 +60,51 @@ func NewContextLoader(cfg *config.Config, log logutils.Log, goenv *goutil.Env,
 	}
 }
 
-func (cl *ContextLoader) prepareBuildContext() {
+// PrepareReload prepares the loader to load packages again in the same process,
+// e.g. in the daemon mode: it forgets the state of the previous loading and the changed files.
+func (cl *ContextLoader) PrepareReload(changedFiles []string) {
+	cl.loadGuard = load.NewGuard()
+	cl.fileCache.Clear()
+	cl.lineCache.Clear()
+	cl.pkgCache.Reset(changedFiles)
+	if cl.astCache != nil {
+		for _, f := range changedFiles {
+			cl.astCache.Forget(f)
+		}
+	}
+}
+
+func (cl *ContextLoader) prepareBuildContext(b *config.BuildCombination) (restore func()) {
 	// Set GOROOT to have working cross-compilation: cross-compiled binaries
 	// have invalid GOROOT. XXX: can't use runtime.GOROOT().
-	goroot := cl.goenv.Get(goutil.EnvGoRoot)
-	if goroot == "" {
-		return
+	if goroot := cl.goenv.Get(goutil.EnvGoRoot); goroot != "" {
+		os.Setenv("GOROOT", goroot)
+		build.Default.GOROOT = goroot
+	}
+
+	return setBuildDefaults(*b, cl.cfg.Run.BuildTagsFor(*b))
+}
+
+// platform of go/build before changes by build combinations
+var defaultGOOS, defaultGOARCH = build.Default.GOOS, build.Default.GOARCH
+
+// setBuildDefaults sets the platform and tags of build.Default for the build combination: some linters,
+// e.g. depguard and asmdecl, read it. It returns a function restoring the previous values.
+func setBuildDefaults(b config.BuildCombination, tags []string) (restore func()) {
+	prevGOOS, prevGOARCH, prevTags := build.Default.GOOS, build.Default.GOARCH, build.Default.BuildTags
+
+	build.Default.GOOS, build.Default.GOARCH = defaultGOOS, defaultGOARCH
+	if b.GOOS != "" {
+		build.Default.GOOS = b.GOOS
 	}
+	if b.GOARCH != "" {
+		build.Default.GOARCH = b.GOARCH
+	}
+	build.Default.BuildTags = tags
 
-	os.Setenv("GOROOT", goroot)
-	build.Default.GOROOT = goroot
-	build.Default.BuildTags = cl.cfg.Run.BuildTags
+	return func() {
+		build.Default.GOOS, build.Default.GOARCH, build.Default.BuildTags = prevGOOS, prevGOARCH, prevTags
+	}
 }
 
 func (cl *ContextLoader) makeFakeLoaderPackageInfo(pkg *packages.Package) *loader.PackageInfo {
@@ -161,6 +196,19 @@ func (cl *ContextLoader) findLoadMode(linters []*linter.Config) packages.LoadMod
 
 func (cl *ContextLoader) buildArgs() []string {
 	args := cl.cfg.Run.Args
+	if files := cl.cfg.Run.FilesToLint(); len(files) != 0 {
+		// load packages enclosing the files: issues are reported only for the files
+		args = nil
+		seenDirs := map[string]bool{}
+		for _, f := range files {
+			dir := filepath.Dir(f)
+			if !seenDirs[dir] {
+				seenDirs[dir] = true
+				args = append(args, dir)
+			}
+		}
+	}
+
 	if len(args) == 0 {
 		return []string{"./..."}
 	}
@@ -178,12 +226,12 @@ func (cl *ContextLoader) buildArgs() []string {
 	return retArgs
 }
 
-func (cl *ContextLoader) makeBuildFlags() ([]string, error) {
+func (cl *ContextLoader) makeBuildFlags(b *config.BuildCombination) ([]string, error) {
 	var buildFlags []string
 
-	if len(cl.cfg.Run.BuildTags) != 0 {
+	if tags := cl.cfg.Run.BuildTagsFor(*b); len(tags) != 0 {
 		// go help build
-		buildFlags = append(buildFlags, "-tags", strings.Join(cl.cfg.Run.BuildTags, " "))
+		buildFlags = append(buildFlags, "-tags", strings.Join(tags, " "))
 	}
 
 	mod := cl.cfg.Run.ModulesDownloadMode
@@ -259,14 +307,15 @@ func (cl *ContextLoader) parseLoadedPackagesErrors(pkgs []*packages.Package) err
 	return nil
 }
 
-func (cl *ContextLoader) loadPackages(ctx context.Context, loadMode packages.LoadMode) ([]*packages.Package, error) {
+func (cl *ContextLoader) loadPackages(ctx context.Context, loadMode packages.LoadMode,
+	t *Target) ([]*packages.Package, error) {
 	defer func(startedAt time.Time) {
 		cl.log.Infof("Go packages loading at mode %s took %s", stringifyLoadMode(loadMode), time.Since(startedAt))
 	}(time.Now())
 
-	cl.prepareBuildContext()
+	defer cl.prepareBuildContext(&t.Build)()
 
-	buildFlags, err := cl.makeBuildFlags()
+	buildFlags, err := cl.makeBuildFlags(&t.Build)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to make build flags for go list")
 	}
@@ -276,11 +325,17 @@ func (cl *ContextLoader) loadPackages(ctx context.Context, loadMode packages.Loa
 		Tests:      cl.cfg.Run.AnalyzeTests,
 		Context:    ctx,
 		BuildFlags: buildFlags,
+		Env:        t.Build.Env(),
+		Dir:        t.Dir,
 		Logf:       cl.debugf,
-		//TODO: use fset, parsefile, overlay
+		Overlay:    cl.fileCache.Overlay(),
+		//TODO: use fset, parsefile
 	}
 
-	args := cl.buildArgs()
+	args := t.Args
+	if len(args) == 0 {
+		args = cl.buildArgs()
+	}
 	cl.debugf("Built loader args are %s", args)
 	pkgs, err := packages.Load(conf, args...)
 	if err != nil {
@@ -370,10 +425,48 @@ func needSSA(linters []*linter.Config) bool {
 	return false
 }
 
-//nolint:gocyclo
+// Target is a part of the program to load: packages of a module for a combination of run.build-matrix
+type Target struct {
+	Dir   string   // directory of the module, the working directory if empty
+	Args  []string // packages to load relative to Dir, run arguments if empty
+	Build config.BuildCombination
+}
+
+func (t Target) String() string {
+	var parts []string
+	if t.Dir != "" {
+		dir, err := fsutils.ShortestRelPath(t.Dir, "")
+		if err != nil {
+			dir = t.Dir
+		}
+		parts = append(parts, "module "+dir)
+	}
+	if b := t.Build.String(); b != "" {
+		parts = append(parts, "build "+b)
+	}
+	if len(parts) == 0 {
+		return "packages"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// ModuleArgs returns run arguments relative to the module directory: modules are directories
+// of all linted modules, packages of nested modules aren't loaded with the parent module.
+// It returns nil if no packages of the module are linted.
+func (cl *ContextLoader) ModuleArgs(module string, modules []string) ([]string, error) {
+	return moduleArgs(module, modules, cl.buildArgs())
+}
+
 func (cl *ContextLoader) Load(ctx context.Context, linters []*linter.Config) (*linter.Context, error) {
+	return cl.LoadTarget(ctx, linters, &Target{})
+}
+
+// LoadTarget loads packages of the target: tags of its build combination are added to run.build-tags.
+//
+//nolint:gocyclo
+func (cl *ContextLoader) LoadTarget(ctx context.Context, linters []*linter.Config, t *Target) (*linter.Context, error) {
 	loadMode := cl.findLoadMode(linters)
-	pkgs, err := cl.loadPackages(ctx, loadMode)
+	pkgs, err := cl.loadPackages(ctx, loadMode, t)
 	if err != nil {
 		return nil, err
 	}
@@ -395,10 +488,11 @@ func (cl *ContextLoader) Load(ctx context.Context, linters []*linter.Config) (*l
 	}
 
 	astLog := cl.log.Child("astcache")
-	astCache, err := astcache.LoadFromPackages(deduplicatedPkgs, astLog)
+	astCache, err := astcache.LoadFromPackages(deduplicatedPkgs, cl.fileCache.Overlay(), cl.astCache, astLog)
 	if err != nil {
 		return nil, err
 	}
+	cl.astCache = astCache
 
 	ret := &linter.Context{
 		Packages: deduplicatedPkgs,
@@ -413,6 +507,8 @@ func (cl *ContextLoader) Load(ctx context.Context, linters []*linter.Config) (*l
 			Cwd:   "",  // used by depguard and fallbacked to os.Getcwd
 			Build: nil, // used by depguard and megacheck and fallbacked to build.Default
 		},
+		Dir:       t.Dir,
+		Build:     t.Build,
 		Cfg:       cl.cfg,
 		ASTCache:  astCache,
 		Log:       cl.log,
@@ -0,0 +1,25 @@
+package lint
+
+import (
+	"go/build"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+)
+
+func TestSetBuildDefaults(t *testing.T) {
+	goos, goarch, tags := build.Default.GOOS, build.Default.GOARCH, build.Default.BuildTags
+
+	restore := setBuildDefaults(config.BuildCombination{GOOS: "plan9", Tags: []string{"integration"}},
+		[]string{"tools", "integration"})
+	assert.Equal(t, "plan9", build.Default.GOOS)
+	assert.Equal(t, goarch, build.Default.GOARCH)
+	assert.Equal(t, []string{"tools", "integration"}, build.Default.BuildTags)
+
+	restore()
+	assert.Equal(t, goos, build.Default.GOOS)
+	assert.Equal(t, goarch, build.Default.GOARCH)
+	assert.Equal(t, tags, build.Default.BuildTags)
+}
@@ -0,0 +1,108 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/golangci/golangci-lint/pkg/fsutils"
+)
+
+// DiscoverModules returns the directory and directories of modules nested in it:
+// subdirectories with go.mod files. Hidden directories, vendor and testdata are skipped.
+func DiscoverModules(root string) ([]string, error) {
+	modules := []string{root}
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() || path == root {
+			return nil
+		}
+
+		name := info.Name()
+		if strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") || name == "vendor" || name == "testdata" {
+			return filepath.SkipDir
+		}
+
+		if fi, err := os.Stat(filepath.Join(path, "go.mod")); err == nil && fi.Mode().IsRegular() {
+			modules = append(modules, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return modules, nil
+}
+
+// isSubpath checks whether the path is the directory or is inside it
+func isSubpath(path, dir string) bool {
+	return path == dir || strings.HasPrefix(path, dir+string(filepath.Separator))
+}
+
+// moduleArgs converts run arguments into arguments relative to the module directory:
+// an argument is passed to the module containing it, a recursive argument (dir/...)
+// is passed also to modules nested in it. modules are absolute directories of all linted modules.
+func moduleArgs(module string, modules, args []string) ([]string, error) {
+	// the module containing the path is the deepest module directory containing it
+	ownerOf := func(path string) string {
+		var owner string
+		for _, m := range modules {
+			if isSubpath(path, m) && len(m) > len(owner) {
+				owner = m
+			}
+		}
+		return owner
+	}
+
+	var ret []string
+	seen := map[string]bool{}
+	add := func(arg string) {
+		if !seen[arg] {
+			seen[arg] = true
+			ret = append(ret, arg)
+		}
+	}
+
+	for _, arg := range args {
+		path := filepath.ToSlash(arg)
+		isRecursive := path == "..." || strings.HasSuffix(path, "/...")
+		if isRecursive {
+			path = strings.TrimSuffix(strings.TrimSuffix(path, "..."), "/")
+			if path == "" {
+				path = "."
+			}
+		}
+
+		absPath, err := filepath.Abs(filepath.FromSlash(path))
+		if err != nil {
+			return nil, err
+		}
+		if evaledPath, err := fsutils.EvalSymlinks(absPath); err == nil {
+			absPath = evaledPath // module directories have evaluated symlinks
+		}
+
+		switch {
+		case ownerOf(absPath) == module:
+			relPath, err := filepath.Rel(module, absPath)
+			if err != nil {
+				return nil, err
+			}
+
+			relArg := "." + string(filepath.Separator) + relPath
+			if relPath == "." {
+				relArg = "."
+			}
+			if isRecursive {
+				relArg += string(filepath.Separator) + "..."
+			}
+			add(relArg)
+		case isRecursive && isSubpath(module, absPath):
+			add("." + string(filepath.Separator) + "...")
+		}
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,69 @@
+package lint
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/golangci/golangci-lint/pkg/fsutils"
+)
+
+func TestModuleArgs(t *testing.T) {
+	wd, err := fsutils.Getwd()
+	require.NoError(t, err)
+
+	root := wd
+	lib := filepath.Join(wd, "lib")
+	libSub := filepath.Join(wd, "lib", "sub")
+	modules := []string{root, lib, libSub}
+
+	testCases := []struct {
+		args   []string
+		module string
+		want   []string
+	}{
+		{args: []string{"./..."}, module: root, want: []string{"./..."}},
+		{args: []string{"./..."}, module: lib, want: []string{"./..."}},
+		{args: []string{"./..."}, module: libSub, want: []string{"./..."}},
+		{args: []string{"./lib/pkg/..."}, module: root, want: nil},
+		{args: []string{"./lib/pkg/..."}, module: lib, want: []string{"./pkg/..."}},
+		{args: []string{"./lib/pkg/..."}, module: libSub, want: nil},
+		{args: []string{"./lib", "./app"}, module: root, want: []string{"./app"}},
+		{args: []string{"./lib", "./app"}, module: lib, want: []string{"."}},
+		{args: []string{"./lib", "./app"}, module: libSub, want: nil},
+		{args: []string{filepath.Join(lib, "...")}, module: libSub, want: []string{"./..."}},
+	}
+
+	for _, tc := range testCases {
+		got, err := moduleArgs(tc.module, modules, tc.args)
+		require.NoError(t, err)
+
+		var want []string
+		for _, arg := range tc.want {
+			want = append(want, filepath.FromSlash(arg))
+		}
+		assert.Equal(t, want, got, "args %v of module %s", tc.args, tc.module)
+	}
+}
+
+func TestDiscoverModules(t *testing.T) {
+	root, err := ioutil.TempDir("", "golangci_lint_modules_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	for _, dir := range []string{"a", "a/b", "c", "vendor/v", ".hidden", "testdata"} {
+		dir = filepath.Join(root, filepath.FromSlash(dir))
+		require.NoError(t, os.MkdirAll(dir, os.ModePerm))
+		if filepath.Base(dir) != "c" {
+			require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "go.mod"), []byte("module m\n"), os.ModePerm))
+		}
+	}
+
+	modules, err := DiscoverModules(root)
+	require.NoError(t, err)
+	assert.Equal(t, []string{root, filepath.Join(root, "a"), filepath.Join(root, "a", "b")}, modules)
+}
@@ -0,0 +1,264 @@
+package lint
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"golang.org/x/tools/go/packages"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/lint/linter"
+	"github.com/golangci/golangci-lint/pkg/lint/lintersdb"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+// nestedConfigs resolves config files of packages: config files in subdirectories of the root directory
+// override issues and linters-settings of the root config for packages below them
+type nestedConfigs struct {
+	rootDir string
+	read    func(configFiles []string) (*config.Config, error)
+
+	filesByDir map[string][]string
+}
+
+// nestedGroup is packages linted with the same config files
+type nestedGroup struct {
+	configFiles []string // nested config files from the outer one, empty for the root config
+	cfg         *config.Config
+	runner      *Runner
+	linters     []*linter.Config
+	builds      []BuildContext
+}
+
+// UseNestedConfigs makes the runner lint packages with config files found in their directories and
+// parents below rootDir: read returns the root config overridden by the nested config files
+func (r *Runner) UseNestedConfigs(rootDir string, read func(configFiles []string) (*config.Config, error)) {
+	r.nested = &nestedConfigs{
+		rootDir:    rootDir,
+		read:       read,
+		filesByDir: map[string][]string{},
+	}
+}
+
+func (n *nestedConfigs) configFiles(dir string) []string {
+	files, ok := n.filesByDir[dir]
+	if !ok {
+		files = config.NestedConfigFiles(n.rootDir, dir)
+		n.filesByDir[dir] = files
+	}
+	return files
+}
+
+// innermostConfigFile identifies the group of packages: the innermost config file determines all outer ones
+func innermostConfigFile(configFiles []string) string {
+	if len(configFiles) == 0 {
+		return ""
+	}
+	return configFiles[len(configFiles)-1]
+}
+
+func packageDir(pkg *packages.Package) string {
+	for _, files := range [][]string{pkg.GoFiles, pkg.CompiledGoFiles, pkg.OtherFiles} {
+		if len(files) != 0 {
+			return filepath.Dir(files[0])
+		}
+	}
+	return ""
+}
+
+// groupByNestedConfigs splits packages of builds into groups linted with the same config files,
+// the root group goes first. It returns nil if all packages are linted with the root config.
+// Contexts of groups have only packages of the group: they are valid only for linters not using
+// the whole program, see runNestedGroups.
+func (r *Runner) groupByNestedConfigs(linters []*linter.Config, builds []BuildContext) []*nestedGroup {
+	// processors shared by groups are finished after linting of all groups
+	rootRunner := *r
+	rootRunner.ownsShared = false
+	root := &nestedGroup{runner: &rootRunner, linters: linters}
+	groups := []*nestedGroup{root}
+	byConfigFile := map[string]*nestedGroup{"": root}
+
+	buildsByGroup := make([]map[*nestedGroup][]*packages.Package, len(builds))
+	for bi, b := range builds {
+		buildsByGroup[bi] = map[*nestedGroup][]*packages.Package{}
+		for _, pkg := range b.LintCtx.Packages {
+			configFiles := r.nested.configFiles(packageDir(pkg))
+			key := innermostConfigFile(configFiles)
+			g, ok := byConfigFile[key]
+			if !ok {
+				g = r.newNestedGroup(configFiles, root)
+				if g != root {
+					groups = append(groups, g)
+				}
+				byConfigFile[key] = g
+			}
+			buildsByGroup[bi][g] = append(buildsByGroup[bi][g], pkg)
+		}
+	}
+	if len(groups) == 1 {
+		return nil
+	}
+
+	for bi, b := range builds {
+		for _, g := range groups {
+			pkgs := buildsByGroup[bi][g]
+			if len(pkgs) == 0 {
+				continue
+			}
+
+			lintCtx := getLintContextForPackages(b.LintCtx, pkgs)
+			if g != root {
+				lintCtx.Cfg = g.cfg
+			}
+			g.builds = append(g.builds, BuildContext{Name: b.Name, LintCtx: lintCtx})
+		}
+	}
+
+	var ret []*nestedGroup
+	for _, g := range groups {
+		if len(g.builds) != 0 {
+			ret = append(ret, g)
+		}
+	}
+	return ret
+}
+
+// newNestedGroup returns the group of packages linted with the config files or the root group
+// if the config files can't be used
+func (r *Runner) newNestedGroup(configFiles []string, root *nestedGroup) *nestedGroup {
+	g, err := r.readNestedGroup(configFiles)
+	if err == nil {
+		r.Log.Infof("Packages below %s are linted with config files %v",
+			filepath.Dir(innermostConfigFile(configFiles)), configFiles)
+		return g
+	}
+
+	r.Log.Errorf("Can't use nested config files %v, packages below %s are linted with the root config: %s",
+		configFiles, filepath.Dir(innermostConfigFile(configFiles)), err)
+	return root
+}
+
+// readNestedGroup reads the config files and makes the runner and linters of the group: linters are
+// made by a linters db of the group config to use its linters-settings, e.g. of govet or custom linters
+func (r *Runner) readNestedGroup(configFiles []string) (*nestedGroup, error) {
+	cfg, err := r.nested.read(configFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	dbManager := lintersdb.NewManager(cfg)
+	if err = dbManager.LoadCustomLinters(); err != nil {
+		return nil, errors.Wrap(err, "can't load custom linters")
+	}
+
+	enabledLintersSet := lintersdb.NewEnabledSet(dbManager,
+		lintersdb.NewValidator(dbManager), r.Log.Child("lintersdb"), cfg)
+	enabledLintersMap, err := enabledLintersSet.GetEnabledLintersMap()
+	if err != nil {
+		return nil, err
+	}
+	linters, err := enabledLintersSet.Get(true)
+	if err != nil {
+		return nil, err
+	}
+
+	runner, err := r.newRunner(cfg, dbManager, enabledLintersMap)
+	if err != nil {
+		return nil, err
+	}
+	runner.ShareProcessors(r.shared)
+
+	return &nestedGroup{configFiles: configFiles, cfg: cfg, runner: runner, linters: linters}, nil
+}
+
+// runNestedGroups lints groups one by one: every group processes issues in files of its packages
+// by its own processors, e.g. exclude rules of its config. The baseline, the diff and limits of issues
+// are shared by all groups. Linters using the whole program run once for all packages of builds
+// with the root config: every group processes their issues in files of its packages.
+func (r *Runner) runNestedGroups(ctx context.Context, linters []*linter.Config, builds []BuildContext,
+	groups []*nestedGroup) <-chan result.Issue {
+	groupFiles := map[string]bool{}
+	for _, g := range groups {
+		groupFiles[innermostConfigFile(g.configFiles)] = true
+	}
+
+	var wholeProgramLinters []*linter.Config
+	for _, lc := range linters {
+		if lc.UsesWholeProgram() {
+			wholeProgramLinters = append(wholeProgramLinters, lc)
+		}
+	}
+
+	// linters report issues in files of other groups, e.g. in cgo files: only the group of the file keeps them
+	inGroup := func(issue *result.Issue, g *nestedGroup) bool {
+		path := issue.FilePath()
+		if !filepath.IsAbs(path) {
+			if absPath, err := filepath.Abs(path); err == nil {
+				path = absPath
+			}
+		}
+		if !isSubpath(path, r.nested.rootDir) {
+			return true // e.g. cgo files in the build cache
+		}
+
+		key := innermostConfigFile(r.nested.configFiles(filepath.Dir(path)))
+		if !groupFiles[key] {
+			key = "" // packages of the file aren't linted with its config files
+		}
+		return key == innermostConfigFile(g.configFiles)
+	}
+
+	lintResultsCh := make(chan lintRes)
+	go func() {
+		defer close(lintResultsCh)
+		var wholeProgramResults []lintRes
+		if len(wholeProgramLinters) != 0 {
+			wholeProgramResults = r.runBuilds(ctx, wholeProgramLinters, builds)
+		}
+		for gi, g := range groups {
+			var groupLinters []*linter.Config
+			for _, lc := range g.linters {
+				if !lc.UsesWholeProgram() {
+					groupLinters = append(groupLinters, lc)
+				}
+			}
+			for _, b := range g.builds {
+				g.runner.recordLinted(g.linters, b.LintCtx)
+			}
+
+			groupResultsCh := make(chan lintRes)
+			go func(gi int, g *nestedGroup) {
+				defer close(groupResultsCh)
+				results := g.runner.runBuilds(ctx, groupLinters, g.builds)
+				for _, res := range wholeProgramResults {
+					if res.err != nil && gi != 0 {
+						continue // the first group reports the error
+					}
+					results = append(results, res)
+				}
+
+				for _, res := range results {
+					var issues []result.Issue
+					for i := range res.issues {
+						if inGroup(&res.issues[i], g) {
+							issues = append(issues, res.issues[i])
+						}
+					}
+					res.issues = issues
+					groupResultsCh <- res
+				}
+			}(gi, g)
+
+			for res := range g.runner.processLintResults(groupResultsCh) {
+				lintResultsCh <- res
+			}
+		}
+
+		if r.ownsShared {
+			r.shared.Finish()
+		}
+	}()
+
+	return collectIssues(lintResultsCh)
+}
@@ -0,0 +1,82 @@
+package lint
+
+import (
+	"context"
+	"go/token"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/tools/go/packages"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/lint/linter"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+// wholeProgramTestLinter reports an issue in the first file of every package and counts runs
+type wholeProgramTestLinter struct {
+	runs int
+}
+
+func (l *wholeProgramTestLinter) Name() string { return "wholeprogramtest" }
+func (l *wholeProgramTestLinter) Desc() string { return "Linter for nested configs tests" }
+
+func (l *wholeProgramTestLinter) Run(_ context.Context, lintCtx *linter.Context) ([]result.Issue, error) {
+	l.runs++
+	var issues []result.Issue
+	for _, pkg := range lintCtx.Packages {
+		issues = append(issues, result.Issue{
+			FromLinter: l.Name(),
+			Text:       "whole program issue in " + pkg.Name,
+			Pos:        token.Position{Filename: pkg.GoFiles[0], Line: 1},
+		})
+	}
+	return issues, nil
+}
+
+func TestRunNestedGroups(t *testing.T) {
+	rootDir := filepath.Join(string(filepath.Separator), "src", "p")
+	subDir := filepath.Join(rootDir, "sub")
+	pkgA := &packages.Package{Name: "a", PkgPath: "p", GoFiles: []string{filepath.Join(rootDir, "a.go")}}
+	pkgB := &packages.Package{Name: "b", PkgPath: "p/sub", GoFiles: []string{filepath.Join(subDir, "b.go")}}
+
+	log := logutils.NewStderrLog("nested_configs_test")
+	cfg := config.NewDefault()
+	cfg.Run.Concurrency = 2
+	newLintCtx := func(pkgs ...*packages.Package) *linter.Context {
+		return &linter.Context{Packages: pkgs, OriginalPackages: pkgs, Cfg: cfg, Log: log}
+	}
+
+	perPackage := &cachedTestLinter{}
+	wholeProgram := &wholeProgramTestLinter{}
+	linters := []*linter.Config{
+		linter.NewConfig(perPackage).WithLoadTypeInfo().WithIssuesCache(),
+		linter.NewConfig(wholeProgram).WithSSA(),
+	}
+
+	r := &Runner{Log: log}
+	r.UseNestedConfigs(rootDir, nil)
+	subConfigFiles := []string{filepath.Join(subDir, ".golangci.yml")}
+	r.nested.filesByDir[rootDir] = nil
+	r.nested.filesByDir[subDir] = subConfigFiles
+
+	groups := []*nestedGroup{
+		{runner: &Runner{Log: log}, linters: linters, builds: []BuildContext{{LintCtx: newLintCtx(pkgA)}}},
+		{configFiles: subConfigFiles, cfg: cfg, runner: &Runner{Log: log}, linters: linters,
+			builds: []BuildContext{{LintCtx: newLintCtx(pkgB)}}},
+	}
+	builds := []BuildContext{{LintCtx: newLintCtx(pkgA, pkgB)}}
+
+	var issues []result.Issue
+	for i := range r.runNestedGroups(context.Background(), linters, builds, groups) {
+		issues = append(issues, i)
+	}
+
+	// the whole program linter runs once for all packages, its issues are reported once
+	assert.Equal(t, 1, wholeProgram.runs)
+	assert.Equal(t, []string{"a", "b"}, perPackage.lintedPkgs)
+	assert.Equal(t, []string{"issue in a", "issue in b", "whole program issue in a", "whole program issue in b"},
+		issueTexts(issues))
+}
@@ -28,10 +28,82 @@ import (
 type Runner struct {
 	Processors []processors.Processor
 	Log        logutils.Log
+
+	linterTimeouts map[string]time.Duration
+	shared         *SharedProcessors
+	ownsShared     bool // shared processors are finished by the runner, not by the caller of ShareProcessors
+	nested         *nestedConfigs
+
+	// newRunner creates a runner for another config and its linters
+	newRunner func(cfg *config.Config, dbManager *lintersdb.Manager,
+		enabledLinters map[string]*linter.Config) (*Runner, error)
+}
+
+// SharedProcessors are processors of issues of all lint groups: issues of all modules and of all packages
+// with nested config files are recorded into one baseline, filtered by one diff and limited together.
+type SharedProcessors struct {
+	processors []processors.Processor
+}
+
+// NewSharedProcessors makes shared processors by issues options of the config
+func NewSharedProcessors(cfg *config.Config, lineCache *fsutils.LineCache, log logutils.Log) (*SharedProcessors, error) {
+	icfg := &cfg.Issues
+	baselineProcessor, err := processors.NewBaseline(icfg.BaselinePath, icfg.WriteBaselinePath,
+		lineCache, log.Child("baseline"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &SharedProcessors{
+		processors: []processors.Processor{
+			baselineProcessor,
+			processors.NewDiff(icfg.Diff, icfg.DiffFromRevision, icfg.DiffPatchFilePath),
+			processors.NewMaxSameIssues(icfg.MaxSameIssues, log.Child("max_same_issues"), cfg),
+			processors.NewMaxFromLinter(icfg.MaxIssuesPerLinter, log.Child("max_from_linter"), cfg),
+		},
+	}, nil
+}
+
+func (s *SharedProcessors) get(name string) processors.Processor {
+	for _, p := range s.processors {
+		if p.Name() == name {
+			return p
+		}
+	}
+	return nil
+}
+
+func (s *SharedProcessors) contains(p processors.Processor) bool {
+	for _, sp := range s.processors {
+		if sp == p {
+			return true
+		}
+	}
+	return false
+}
+
+// Finish finishes shared processors after all runners sharing them are run
+func (s *SharedProcessors) Finish() {
+	for _, p := range s.processors {
+		p.Finish()
+	}
+}
+
+// ShareProcessors makes the runner use the shared processors instead of its own ones: they aren't
+// finished by the runner, the caller finishes them by SharedProcessors.Finish after all runs.
+func (r *Runner) ShareProcessors(s *SharedProcessors) {
+	for i, p := range r.Processors {
+		if sp := s.get(p.Name()); sp != nil {
+			r.Processors[i] = sp
+		}
+	}
+	r.shared = s
+	r.ownsShared = false
 }
 
 func NewRunner(astCache *astcache.Cache, cfg *config.Config, log logutils.Log, goenv *goutil.Env,
-	lineCache *fsutils.LineCache, dbManager *lintersdb.Manager) (*Runner, error) {
+	lineCache *fsutils.LineCache, dbManager *lintersdb.Manager,
+	enabledLinters map[string]*linter.Config) (*Runner, error) {
 	icfg := cfg.Issues
 	excludePatterns := icfg.ExcludePatterns
 	if icfg.UseDefaultExcludes {
@@ -52,7 +124,16 @@ func NewRunner(astCache *astcache.Cache, cfg *config.Config, log logutils.Log, g
 	if cfg.Run.UseDefaultSkipDirs {
 		skipDirs = append(skipDirs, packages.StdExcludeDirRegexps...)
 	}
-	skipDirsProcessor, err := processors.NewSkipDirs(skipDirs, log.Child("skip dirs"), cfg.Run.Args)
+	runArgs := cfg.Run.Args
+	if files := cfg.Run.FilesToLint(); len(files) != 0 {
+		runArgs = files
+	}
+	skipDirsProcessor, err := processors.NewSkipDirs(skipDirs, log.Child("skip dirs"), runArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	shared, err := NewSharedProcessors(cfg, lineCache, log)
 	if err != nil {
 		return nil, err
 	}
@@ -60,18 +141,46 @@ func NewRunner(astCache *astcache.Cache, cfg *config.Config, log logutils.Log, g
 	var excludeRules []processors.ExcludeRule
 	for _, r := range icfg.ExcludeRules {
 		excludeRules = append(excludeRules, processors.ExcludeRule{
-			Text:    r.Text,
-			Source:  r.Source,
-			Path:    r.Path,
-			Linters: r.Linters,
+			BaseRule: processors.BaseRule{
+				Text:    r.Text,
+				Source:  r.Source,
+				Path:    r.Path,
+				Linters: r.Linters,
+			},
 		})
 	}
 
-	return &Runner{
+	linterTimeouts := map[string]time.Duration{}
+	for name, timeout := range cfg.LintersSettings.Timeouts {
+		lc := dbManager.GetLinterConfig(name)
+		if lc == nil {
+			return nil, fmt.Errorf("linters-settings.timeouts: no such linter %s", name)
+		}
+		if !lc.IsContextAware {
+			return nil, fmt.Errorf("linters-settings.timeouts: linter %s can't be stopped on a timeout", name)
+		}
+		linterTimeouts[lc.Name()] = timeout
+	}
+
+	var severityRules []processors.SeverityRule
+	for _, r := range cfg.Severity.Rules {
+		severityRules = append(severityRules, processors.SeverityRule{
+			Severity: r.Severity,
+			BaseRule: processors.BaseRule{
+				Text:    r.Text,
+				Source:  r.Source,
+				Path:    r.Path,
+				Linters: r.Linters,
+			},
+		})
+	}
+
+	r := &Runner{
 		Processors: []processors.Processor{
 			processors.NewCgo(goenv),
 			processors.NewFilenameUnadjuster(astCache, log.Child("filename_unadjuster")), // must go after Cgo
 			processors.NewPathPrettifier(), // must be before diff, nolint and exclude autogenerated processor at least
+			processors.NewFiles(cfg.Run.FilesToLint(), log.Child("files")),
 			skipFilesProcessor,
 			skipDirsProcessor, // must be after path prettifier
 
@@ -79,18 +188,29 @@ func NewRunner(astCache *astcache.Cache, cfg *config.Config, log logutils.Log, g
 			processors.NewIdentifierMarker(), // must be before exclude because users see already marked output and configure excluding by it
 			processors.NewExclude(excludeTotalPattern),
 			processors.NewExcludeRules(excludeRules, lineCache, log.Child("exclude_rules")),
-			processors.NewNolint(astCache, log.Child("nolint"), dbManager),
+			processors.NewNolint(astCache, log.Child("nolint"), dbManager,
+				enabledLinters, &cfg.LintersSettings.Nolintlint),
+			shared.get("baseline"), // must be before all limiting processors to record all issues
 
 			processors.NewUniqByLine(cfg),
-			processors.NewDiff(icfg.Diff, icfg.DiffFromRevision, icfg.DiffPatchFilePath),
+			shared.get("diff"),
 			processors.NewMaxPerFileFromLinter(cfg),
-			processors.NewMaxSameIssues(icfg.MaxSameIssues, log.Child("max_same_issues"), cfg),
-			processors.NewMaxFromLinter(icfg.MaxIssuesPerLinter, log.Child("max_from_linter"), cfg),
+			shared.get("max_same_issues"),
+			shared.get("max_from_linter"),
+			processors.NewSeverityRules(cfg.Severity.Default, severityRules, lineCache, log.Child("severity_rules")),
 			processors.NewSourceCode(lineCache, log.Child("source_code")),
 			processors.NewPathShortener(),
 		},
-		Log: log,
-	}, nil
+		Log:            log,
+		linterTimeouts: linterTimeouts,
+		shared:         shared,
+		ownsShared:     true,
+	}
+	r.newRunner = func(cfg *config.Config, dbManager *lintersdb.Manager,
+		enabledLinters map[string]*linter.Config) (*Runner, error) {
+		return NewRunner(astCache, cfg, log, goenv, lineCache, dbManager, enabledLinters)
+	}
+	return r, nil
 }
 
 type lintRes struct {
@@ -115,7 +235,7 @@ func (r *Runner) runLinterSafe(ctx context.Context, lintCtx *linter.Context,
 
 	specificLintCtx := *lintCtx
 	specificLintCtx.Log = r.Log.Child(lc.Name())
-	issues, err := lc.Linter.Run(ctx, &specificLintCtx)
+	issues, err := r.runLinterWithCache(ctx, &specificLintCtx, lc)
 	if err != nil {
 		return nil, err
 	}
@@ -127,6 +247,31 @@ func (r *Runner) runLinterSafe(ctx context.Context, lintCtx *linter.Context,
 	return issues, nil
 }
 
+// runLinterWithTimeout runs the linter with the timeout from linters-settings.timeouts.
+// Only context aware linters can have timeouts: the timed out linter stops soon,
+// its issues are dropped, other linters aren't affected.
+func (r Runner) runLinterWithTimeout(ctx context.Context, lintCtx *linter.Context,
+	lc *linter.Config) ([]result.Issue, error) {
+	timeout := r.linterTimeouts[lc.Name()]
+	if timeout == 0 {
+		return r.runLinterSafe(ctx, lintCtx, lc)
+	}
+
+	linterCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// wait for the linter to stop: a linter running in the background would race with next linters
+	issues, err := r.runLinterSafe(linterCtx, lintCtx, lc)
+	if err == nil || linterCtx.Err() == nil {
+		return issues, err
+	}
+
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	return nil, fmt.Errorf("timed out after %s: increase it by linters-settings.timeouts", timeout)
+}
+
 func (r Runner) runWorker(ctx context.Context, lintCtx *linter.Context,
 	tasksCh <-chan *linter.Config, lintResultsCh chan<- lintRes, name string) {
 	sw := timeutils.NewStopwatch(name, r.Log)
@@ -148,7 +293,7 @@ func (r Runner) runWorker(ctx context.Context, lintCtx *linter.Context,
 			var issues []result.Issue
 			var err error
 			sw.TrackStage(lc.Name(), func() {
-				issues, err = r.runLinterSafe(ctx, lintCtx, lc)
+				issues, err = r.runLinterWithTimeout(ctx, lintCtx, lc)
 			})
 			lintResultsCh <- lintRes{
 				linter: lc,
@@ -197,6 +342,9 @@ func (r *Runner) runWorkers(ctx context.Context, lintCtx *linter.Context, linter
 
 	workersFinishTimes := make([]time.Time, lintCtx.Cfg.Run.Concurrency)
 
+	// builds are linted one by one: go/build defaults are set for the build while its linters run
+	restoreBuildDefaults := setBuildDefaults(lintCtx.Build, lintCtx.BuildTags())
+
 	for i := 0; i < lintCtx.Cfg.Run.Concurrency; i++ {
 		wg.Add(1)
 		go func(i int) {
@@ -215,6 +363,7 @@ func (r *Runner) runWorkers(ctx context.Context, lintCtx *linter.Context, linter
 
 	go func() {
 		wg.Wait()
+		restoreBuildDefaults()
 		close(lintResultsCh)
 
 		r.logWorkersStat(workersFinishTimes)
@@ -241,6 +390,7 @@ func (r Runner) processLintResults(inCh <-chan lintRes) <-chan lintRes {
 		for res := range inCh {
 			if res.err != nil {
 				r.Log.Warnf("Can't run linter %s: %s", res.linter.Name(), res.err)
+				r.recordFailedLinter(res.linter.Name())
 				continue
 			}
 
@@ -252,10 +402,32 @@ func (r Runner) processLintResults(inCh <-chan lintRes) <-chan lintRes {
 			}
 		}
 
+		// some processors can report own issues only after processing all issues
+		for _, p := range r.Processors {
+			reporter, ok := p.(processors.IssuesReporter)
+			if !ok {
+				continue
+			}
+
+			var issues []result.Issue
+			sw.TrackStage(p.Name(), func() {
+				issues = reporter.ReportedIssues()
+			})
+			if len(issues) != 0 {
+				issuesBefore += len(issues)
+				issues = r.processIssues(issues, sw, statPerProcessor)
+				issuesAfter += len(issues)
+				outCh <- lintRes{issues: issues}
+			}
+		}
+
 		// finalize processors: logging, clearing, no heavy work here
 
 		for _, p := range r.Processors {
 			p := p
+			if !r.ownsShared && r.shared.contains(p) {
+				continue // they are finished after all runners sharing them
+			}
 			sw.TrackStage(p.Name(), func() {
 				p.Finish()
 			})
@@ -271,6 +443,33 @@ func (r Runner) processLintResults(inCh <-chan lintRes) <-chan lintRes {
 	return outCh
 }
 
+// recordLinted passes linters and files of packages to processors depending on what was linted
+func (r Runner) recordLinted(linters []*linter.Config, lintCtxs ...*linter.Context) {
+	var linterNames, files []string
+	for _, lc := range linters {
+		linterNames = append(linterNames, lc.Name())
+	}
+	for _, lintCtx := range lintCtxs {
+		for _, pkg := range lintCtx.Packages {
+			files = append(files, pkg.GoFiles...)
+		}
+	}
+
+	for _, p := range r.Processors {
+		if recorder, ok := p.(processors.LintedRecorder); ok {
+			recorder.RecordLinted(linterNames, files)
+		}
+	}
+}
+
+func (r Runner) recordFailedLinter(name string) {
+	for _, p := range r.Processors {
+		if recorder, ok := p.(processors.FailedLintersRecorder); ok {
+			recorder.RecordFailedLinter(name)
+		}
+	}
+}
+
 func (r Runner) printPerProcessorStat(stat map[string]processorStat) {
 	parts := make([]string, 0, len(stat))
 	for name, ps := range stat {
@@ -303,6 +502,14 @@ func collectIssues(resCh <-chan lintRes) <-chan result.Issue {
 }
 
 func (r Runner) Run(ctx context.Context, linters []*linter.Config, lintCtx *linter.Context) <-chan result.Issue {
+	if r.nested != nil {
+		builds := []BuildContext{{LintCtx: lintCtx}}
+		if groups := r.groupByNestedConfigs(linters, builds); groups != nil {
+			return r.runNestedGroups(ctx, linters, builds, groups)
+		}
+	}
+
+	r.recordLinted(linters, lintCtx)
 	lintResultsCh := r.runWorkers(ctx, lintCtx, linters)
 	processedLintResultsCh := r.processLintResults(lintResultsCh)
 	if ctx.Err() != nil {
@@ -319,6 +526,102 @@ func (r Runner) Run(ctx context.Context, linters []*linter.Config, lintCtx *lint
 	return collectIssues(processedLintResultsCh)
 }
 
+// BuildContext is a linters context loaded for a target, e.g. for a module or a combination of run.build-matrix
+type BuildContext struct {
+	Name    string // name of the build combination, empty if run.build-matrix isn't used
+	LintCtx *linter.Context
+}
+
+// RunBuildMatrix runs linters for every context and processes their issues once:
+// the same issue found in several build combinations is reported once with names of all of them.
+func (r Runner) RunBuildMatrix(ctx context.Context, linters []*linter.Config, builds []BuildContext) <-chan result.Issue {
+	if r.nested != nil {
+		if groups := r.groupByNestedConfigs(linters, builds); groups != nil {
+			return r.runNestedGroups(ctx, linters, builds, groups)
+		}
+	}
+
+	for _, b := range builds {
+		r.recordLinted(linters, b.LintCtx)
+	}
+	lintResultsCh := make(chan lintRes)
+	go func() {
+		defer close(lintResultsCh)
+		for _, res := range r.runBuilds(ctx, linters, builds) {
+			lintResultsCh <- res
+		}
+	}()
+
+	return collectIssues(r.processLintResults(lintResultsCh))
+}
+
+type buildIssueKey struct {
+	linter, filename, text string
+	line, column           int
+}
+
+func (r Runner) runBuilds(ctx context.Context, linters []*linter.Config, builds []BuildContext) []lintRes {
+	var ret []lintRes
+	linterResIndexes := map[string]int{}
+	type issueIndex struct{ res, issue int }
+	seen := map[buildIssueKey]issueIndex{}
+
+	for _, b := range builds {
+		finishedLintersN := 0
+		for res := range r.runWorkers(ctx, b.LintCtx, linters) {
+			finishedLintersN++
+			if res.err != nil {
+				if b.Name != "" {
+					res.err = fmt.Errorf("build %s: %s", b.Name, res.err)
+				}
+				ret = append(ret, res)
+				continue
+			}
+
+			resIndex, ok := linterResIndexes[res.linter.Name()]
+			if !ok {
+				ret = append(ret, lintRes{linter: res.linter})
+				resIndex = len(ret) - 1
+				linterResIndexes[res.linter.Name()] = resIndex
+			}
+
+			for _, i := range res.issues {
+				if b.Name == "" {
+					ret[resIndex].issues = append(ret[resIndex].issues, i)
+					continue
+				}
+
+				key := buildIssueKey{
+					linter:   i.FromLinter,
+					filename: i.FilePath(),
+					text:     i.Text,
+					line:     i.Line(),
+					column:   i.Column(),
+				}
+
+				if idx, ok := seen[key]; ok {
+					same := &ret[idx.res].issues[idx.issue]
+					if same.BuildCombinations[len(same.BuildCombinations)-1] != b.Name {
+						same.BuildCombinations = append(same.BuildCombinations, b.Name)
+						continue
+					} // else it's a duplicate reported by the linter: keep it for processors
+				}
+
+				i.BuildCombinations = []string{b.Name}
+				seen[key] = issueIndex{res: resIndex, issue: len(ret[resIndex].issues)}
+				ret[resIndex].issues = append(ret[resIndex].issues, i)
+			}
+		}
+
+		if ctx.Err() != nil {
+			r.Log.Errorf("%d/%d linters finished: deadline exceeded", finishedLintersN, len(linters))
+			break
+		}
+	}
+
+	return ret
+}
+
 func (r *Runner) processIssues(issues []result.Issue, sw *timeutils.Stopwatch, statPerProcessor map[string]processorStat) []result.Issue {
 	for _, p := range r.Processors {
 		var newIssues []result.Issue
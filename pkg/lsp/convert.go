@@ -0,0 +1,242 @@
+package lsp
+
+import (
+	"fmt"
+	"go/scanner"
+	"go/token"
+	"net/url"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+const diagnosticSource = "golangci-lint"
+
+func uriToPath(uri string) (string, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", err
+	}
+	if u.Scheme != "file" {
+		return "", fmt.Errorf("unsupported URI scheme of %s", uri)
+	}
+
+	path := u.Path
+	if runtime.GOOS == "windows" {
+		path = strings.TrimPrefix(path, "/") // /C:/dir
+	}
+	return filepath.FromSlash(path), nil
+}
+
+func pathToURI(path string) string {
+	path = filepath.ToSlash(path)
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path // C:/dir
+	}
+	return (&url.URL{Scheme: "file", Path: path}).String()
+}
+
+// utf16Len returns a length of the string in UTF-16 code units: LSP uses it for columns
+func utf16Len(s string) int {
+	n := 0
+	for _, r := range s {
+		if utf16.IsSurrogate(r) || r < 0x10000 {
+			n++
+		} else {
+			n += 2 // surrogate pair
+		}
+	}
+	return n
+}
+
+// text is a document split into lines
+type text []string
+
+func newText(s string) text {
+	return strings.Split(s, "\n")
+}
+
+func (t text) line(line0 int) string {
+	if line0 < 0 || line0 >= len(t) {
+		return ""
+	}
+	return strings.TrimSuffix(t[line0], "\r")
+}
+
+// position returns the position of a one-based line and a one-based byte column,
+// zero column means the line start
+func (t text) position(line1, col1 int) Position {
+	line := t.line(line1 - 1)
+	col0 := col1 - 1
+	if col0 < 0 {
+		col0 = 0
+	}
+	if col0 > len(line) {
+		col0 = len(line)
+	}
+
+	return Position{Line: line1 - 1, Character: utf16Len(line[:col0])}
+}
+
+func (t text) lineEnd(line1 int) Position {
+	return Position{Line: line1 - 1, Character: utf16Len(t.line(line1 - 1))}
+}
+
+// offsetPosition returns the position of a zero-based byte offset
+func (t text) offsetPosition(offset int) Position {
+	lineStart := 0
+	for i, line := range t {
+		lineEnd := lineStart + len(line)
+		if offset <= lineEnd {
+			col := offset - lineStart
+			if col > len(t.line(i)) {
+				col = len(t.line(i)) // offset is inside \r\n
+			}
+			return Position{Line: i, Character: utf16Len(line[:col])}
+		}
+		lineStart = lineEnd + 1 // \n
+	}
+
+	return t.lineEnd(len(t))
+}
+
+func diagnosticSeverity(severity string) int {
+	switch strings.ToLower(severity) {
+	case "error":
+		return SeverityError
+	case "info", "information", "note":
+		return SeverityInformation
+	case "hint", "suggestion":
+		return SeverityHint
+	default:
+		return SeverityWarning
+	}
+}
+
+func issueDiagnostic(t text, i *result.Issue) Diagnostic {
+	lineRange := i.GetLineRange()
+	return Diagnostic{
+		Range: Range{
+			Start: t.position(i.Line(), i.Column()),
+			End:   t.lineEnd(lineRange.To),
+		},
+		Severity: diagnosticSeverity(i.Severity),
+		Code:     i.FromLinter,
+		Source:   diagnosticSource,
+		Message:  i.Text,
+	}
+}
+
+// rangesOverlap checks whether ranges overlap, touching ranges are considered overlapping
+func rangesOverlap(a, b Range) bool {
+	return !positionLess(a.End, b.Start) && !positionLess(b.End, a.Start)
+}
+
+func positionLess(a, b Position) bool {
+	return a.Line < b.Line || (a.Line == b.Line && a.Character < b.Character)
+}
+
+// replacementEdits converts the replacement of the issue in the document with the URI into text edits by
+// document URIs. getText and uriOf return text and URI of a file: the issue can contain edits of other files.
+func replacementEdits(t text, uri string, i *result.Issue,
+	getText func(path string) (text, error), uriOf func(path string) string) (map[string][]TextEdit, error) {
+	r := i.Replacement
+	if r == nil {
+		return nil, nil
+	}
+
+	if len(r.TextEdits) != 0 {
+		ret := map[string][]TextEdit{}
+		for _, edit := range r.TextEdits {
+			editText, err := getText(edit.Filename)
+			if err != nil {
+				return nil, err
+			}
+
+			editURI := uriOf(edit.Filename)
+			ret[editURI] = append(ret[editURI], TextEdit{
+				Range: Range{
+					Start: editText.offsetPosition(edit.Offset),
+					End:   editText.offsetPosition(edit.EndOffset),
+				},
+				NewText: edit.NewText,
+			})
+		}
+		return ret, nil
+	}
+
+	var edit TextEdit
+	lineRange := i.GetLineRange()
+	switch {
+	case r.Inline != nil:
+		edit.Range = Range{
+			Start: t.position(i.Line(), r.Inline.StartCol+1),
+			End:   t.position(i.Line(), r.Inline.StartCol+r.Inline.Length+1),
+		}
+		edit.NewText = r.Inline.NewString
+	case r.NeedOnlyDelete:
+		edit.Range = Range{
+			Start: Position{Line: lineRange.From - 1},
+			End:   Position{Line: lineRange.To},
+		}
+	default:
+		edit.Range = Range{
+			Start: Position{Line: lineRange.From - 1},
+			End:   Position{Line: lineRange.To},
+		}
+		edit.NewText = strings.Join(r.NewLines, "\n") + "\n"
+	}
+
+	return map[string][]TextEdit{uri: {edit}}, nil
+}
+
+// nolintEdit returns an edit adding a nolint comment for the linter to the line of the issue: it's appended
+// to the line or inserted before the comment ending the line, e.g. `//nolint:godox // TODO`.
+// It returns nil if the line already has a nolint comment.
+func nolintEdit(t text, i *result.Issue) *TextEdit {
+	line := t.line(i.Line() - 1)
+	if strings.Contains(line, "//nolint") {
+		return nil
+	}
+
+	// the line can end inside a multiline comment or string: nolint comment can't be added there
+	if strings.Contains(line, "/*") && !strings.Contains(line, "*/") || strings.Count(line, "`")%2 == 1 {
+		return nil
+	}
+
+	// nolint directive must start the comment: `// TODO //nolint:godox` isn't a directive
+	if commentStart := lineCommentStart(line); commentStart != -1 {
+		start := t.position(i.Line(), commentStart+1)
+		return &TextEdit{
+			Range:   Range{Start: start, End: start},
+			NewText: "//nolint:" + i.FromLinter + " ",
+		}
+	}
+
+	end := t.lineEnd(i.Line())
+	return &TextEdit{
+		Range:   Range{Start: end, End: end},
+		NewText: " //nolint:" + i.FromLinter,
+	}
+}
+
+// lineCommentStart returns the byte offset of the // comment in the line or -1 if the line has no such comment
+func lineCommentStart(line string) int {
+	fset := token.NewFileSet()
+	f := fset.AddFile("", -1, len(line))
+
+	var s scanner.Scanner
+	s.Init(f, []byte(line), nil, scanner.ScanComments)
+	for {
+		pos, tok, lit := s.Scan()
+		if tok == token.EOF {
+			return -1
+		}
+		if tok == token.COMMENT && strings.HasPrefix(lit, "//") {
+			return f.Offset(pos)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package lsp
+
+import (
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func TestURIPath(t *testing.T) {
+	path, err := uriToPath("file:///tmp/dir%20with%20spaces/a.go")
+	require.NoError(t, err)
+	assert.Equal(t, "/tmp/dir with spaces/a.go", path)
+	assert.Equal(t, "file:///tmp/dir%20with%20spaces/a.go", pathToURI(path))
+
+	_, err = uriToPath("untitled:Untitled-1")
+	assert.Error(t, err)
+}
+
+func TestTextPositions(t *testing.T) {
+	txt := newText("package p\r\n\n// привет 😀 x\n")
+
+	assert.Equal(t, Position{Line: 0, Character: 8}, txt.position(1, 9))
+	assert.Equal(t, Position{Line: 0, Character: 9}, txt.lineEnd(1))
+	assert.Equal(t, Position{Line: 2, Character: 0}, txt.position(3, 0))
+
+	// columns are in UTF-16 code units: the emoji takes two of them
+	assert.Equal(t, Position{Line: 2, Character: 13}, txt.position(3, len("// привет 😀 x")))
+	assert.Equal(t, 14, utf16Len("// привет 😀 x"))
+
+	assert.Equal(t, Position{Line: 0, Character: 9}, txt.offsetPosition(10)) // inside \r\n
+	assert.Equal(t, Position{Line: 1, Character: 0}, txt.offsetPosition(11))
+	assert.Equal(t, Position{Line: 2, Character: 3}, txt.offsetPosition(15))
+}
+
+func TestIssueDiagnostic(t *testing.T) {
+	txt := newText("package p\n\nvar x = 1 // TODO\n")
+	i := result.Issue{
+		FromLinter: "godox",
+		Text:       "TODO found",
+		Pos:        token.Position{Filename: "a.go", Line: 3, Column: 12},
+	}
+
+	d := issueDiagnostic(txt, &i)
+	assert.Equal(t, Range{Start: Position{Line: 2, Character: 11}, End: Position{Line: 2, Character: 17}}, d.Range)
+	assert.Equal(t, SeverityWarning, d.Severity)
+	assert.Equal(t, "godox", d.Code)
+	assert.Equal(t, "TODO found", d.Message)
+
+	i.Severity = "error"
+	assert.Equal(t, SeverityError, issueDiagnostic(txt, &i).Severity)
+}
+
+func TestReplacementEdits(t *testing.T) {
+	txt := newText("package p\n\nvar  x = 1\nvar y = 2\n")
+	const uri = "file:///a.go"
+	noText := func(path string) (text, error) {
+		t.Fatalf("unexpected text request of %s", path)
+		return nil, nil
+	}
+	noURI := func(path string) string {
+		t.Fatalf("unexpected URI request of %s", path)
+		return ""
+	}
+
+	i := result.Issue{
+		Pos: token.Position{Filename: "a.go", Line: 3},
+		Replacement: &result.Replacement{
+			Inline: &result.InlineFix{StartCol: 3, Length: 2, NewString: " "},
+		},
+	}
+	edits, err := replacementEdits(txt, uri, &i, noText, noURI)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]TextEdit{uri: {{
+		Range:   Range{Start: Position{Line: 2, Character: 3}, End: Position{Line: 2, Character: 5}},
+		NewText: " ",
+	}}}, edits)
+
+	i.Replacement = &result.Replacement{NeedOnlyDelete: true}
+	i.LineRange = &result.Range{From: 3, To: 4}
+	edits, err = replacementEdits(txt, uri, &i, noText, noURI)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]TextEdit{uri: {{
+		Range: Range{Start: Position{Line: 2}, End: Position{Line: 4}},
+	}}}, edits)
+
+	i.Replacement = &result.Replacement{NewLines: []string{"var x = 1"}}
+	i.LineRange = nil
+	edits, err = replacementEdits(txt, uri, &i, noText, noURI)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]TextEdit{uri: {{
+		Range:   Range{Start: Position{Line: 2}, End: Position{Line: 3}},
+		NewText: "var x = 1\n",
+	}}}, edits)
+
+	// edits of other files use their texts and URIs
+	i.Replacement = &result.Replacement{TextEdits: []result.TextEdit{
+		{Filename: "/b.go", Offset: 8, EndOffset: 9, NewText: "q"},
+	}}
+	edits, err = replacementEdits(txt, uri, &i,
+		func(path string) (text, error) { return newText("package p\n"), nil },
+		func(path string) string { return "file://" + path })
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]TextEdit{"file:///b.go": {{
+		Range:   Range{Start: Position{Line: 0, Character: 8}, End: Position{Line: 0, Character: 9}},
+		NewText: "q",
+	}}}, edits)
+
+	i.Replacement = nil
+	edits, err = replacementEdits(txt, uri, &i, noText, noURI)
+	require.NoError(t, err)
+	assert.Empty(t, edits)
+}
+
+func TestNolintEdit(t *testing.T) {
+	txt := newText("var x = 1\nvar y = 2 //nolint\nvar s = `a\nb`\nvar z = \"//\" // TODO: z\n")
+	i := result.Issue{FromLinter: "godox", Pos: token.Position{Filename: "a.go", Line: 1}}
+
+	edit := nolintEdit(txt, &i)
+	require.NotNil(t, edit)
+	end := Position{Line: 0, Character: 9}
+	assert.Equal(t, TextEdit{Range: Range{Start: end, End: end}, NewText: " //nolint:godox"}, *edit)
+
+	i.Pos.Line = 2
+	assert.Nil(t, nolintEdit(txt, &i), "line already has nolint comment")
+
+	i.Pos.Line = 3
+	assert.Nil(t, nolintEdit(txt, &i), "line ends inside a raw string")
+
+	// the directive is inserted before the comment: after it the directive isn't recognized
+	i.Pos.Line = 5
+	edit = nolintEdit(txt, &i)
+	require.NotNil(t, edit)
+	start := Position{Line: 4, Character: 13}
+	assert.Equal(t, TextEdit{Range: Range{Start: start, End: start}, NewText: "//nolint:godox "}, *edit)
+}
@@ -0,0 +1,566 @@
+package lsp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/golangci/golangci-lint/pkg/fsutils"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+// LintFunc lints the file: contents of files from the overlay (by absolute paths)
+// are used instead of contents of files on disk.
+type LintFunc func(ctx context.Context, path string, overlay map[string][]byte) ([]result.Issue, error)
+
+// document is a text document opened in the editor
+type document struct {
+	path    string
+	content string
+	version int
+
+	// issues, text and its version of the last linting: issues positions are for this text
+	issues      []result.Issue
+	text        text
+	textVersion int
+}
+
+// Server is a language server publishing issues as diagnostics
+// and providing code actions to fix them
+type Server struct {
+	wd   string // relative paths of issues are relative to it
+	lint LintFunc
+	log  logutils.Log
+	conn *conn
+
+	// the server can't reload the config: it asks to restart it on changes of config files
+	isConfigFile   func(path string) bool
+	configChanged  <-chan string // changed config files found by the linter
+	notifiedConfig map[string]bool
+
+	mu        sync.Mutex
+	docs      map[string]*document // by URI
+	pending   map[string]bool      // URIs of documents to lint
+	lintReady chan struct{}
+
+	shutdown bool
+}
+
+func NewServer(wd string, lint LintFunc, isConfigFile func(path string) bool, configChanged <-chan string,
+	log logutils.Log) *Server {
+	return &Server{
+		wd:             wd,
+		lint:           lint,
+		log:            log,
+		isConfigFile:   isConfigFile,
+		configChanged:  configChanged,
+		notifiedConfig: map[string]bool{},
+		docs:           map[string]*document{},
+		pending:        map[string]bool{},
+		lintReady:      make(chan struct{}, 1),
+	}
+}
+
+// Run serves LSP messages from r until the exit notification or the end of input.
+// It returns false if the client exited without the shutdown request.
+func (s *Server) Run(ctx context.Context, r io.Reader, w io.Writer) (bool, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	s.conn = newConn(r, w)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		s.lintLoop(ctx)
+	}()
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case configFile := <-s.configChanged:
+				s.notifyConfigChanged(configFile)
+			}
+		}
+	}()
+	defer wg.Wait()
+	defer cancel()
+
+	for {
+		msg, err := s.conn.read()
+		if err != nil {
+			if err == io.EOF {
+				return s.isShutdown(), nil
+			}
+			if rerr, ok := err.(*responseError); ok {
+				if err = s.conn.reply(nil, nil, rerr); err != nil {
+					return false, err
+				}
+				continue
+			}
+			return false, errors.Wrap(err, "failed to read message")
+		}
+
+		if msg.Method == "exit" {
+			return s.isShutdown(), nil
+		}
+
+		result, err := s.handle(msg)
+		if msg.ID == nil { // notification
+			if err != nil {
+				s.log.Warnf("Can't handle %s: %s", msg.Method, err)
+			}
+			continue
+		}
+
+		if err = s.conn.reply(msg.ID, result, err); err != nil {
+			return false, errors.Wrap(err, "failed to reply")
+		}
+	}
+}
+
+func (s *Server) isShutdown() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.shutdown
+}
+
+func unmarshalParams(msg *message, params interface{}) error {
+	if msg.Params == nil {
+		return &responseError{Code: codeInvalidParams, Message: "no params"}
+	}
+	if err := json.Unmarshal(*msg.Params, params); err != nil {
+		return &responseError{Code: codeInvalidParams, Message: fmt.Sprintf("invalid params: %s", err)}
+	}
+	return nil
+}
+
+func (s *Server) handle(msg *message) (interface{}, error) {
+	switch msg.Method {
+	case "initialize":
+		var res InitializeResult
+		res.Capabilities = ServerCapabilities{
+			TextDocumentSync: TextDocumentSyncOptions{
+				OpenClose: true,
+				Change:    textDocumentSyncKindFull,
+				Save:      true,
+			},
+			CodeActionProvider: true,
+		}
+		res.ServerInfo.Name = "golangci-lint"
+		return res, nil
+	case "initialized":
+		return nil, nil
+	case "shutdown":
+		s.mu.Lock()
+		s.shutdown = true
+		s.mu.Unlock()
+		return nil, nil
+	case "textDocument/didOpen":
+		var params DidOpenTextDocumentParams
+		if err := unmarshalParams(msg, &params); err != nil {
+			return nil, err
+		}
+		return nil, s.didOpen(&params)
+	case "textDocument/didChange":
+		var params DidChangeTextDocumentParams
+		if err := unmarshalParams(msg, &params); err != nil {
+			return nil, err
+		}
+		return nil, s.didChange(&params)
+	case "textDocument/didSave":
+		var params DidSaveTextDocumentParams
+		if err := unmarshalParams(msg, &params); err != nil {
+			return nil, err
+		}
+		return nil, s.didSave(&params)
+	case "textDocument/didClose":
+		var params DidCloseTextDocumentParams
+		if err := unmarshalParams(msg, &params); err != nil {
+			return nil, err
+		}
+		return nil, s.didClose(&params)
+	case "workspace/didChangeConfiguration":
+		s.notifyConfigChanged("")
+		return nil, nil
+	case "workspace/didChangeWatchedFiles":
+		var params DidChangeWatchedFilesParams
+		if err := unmarshalParams(msg, &params); err != nil {
+			return nil, err
+		}
+		return nil, s.didChangeWatchedFiles(&params)
+	case "textDocument/codeAction":
+		var params CodeActionParams
+		if err := unmarshalParams(msg, &params); err != nil {
+			return nil, err
+		}
+		return s.codeActions(&params)
+	default:
+		return nil, &responseError{Code: codeMethodNotFound, Message: fmt.Sprintf("method %s isn't supported", msg.Method)}
+	}
+}
+
+func (s *Server) didOpen(params *DidOpenTextDocumentParams) error {
+	path, err := uriToPath(params.TextDocument.URI)
+	if err != nil {
+		return err
+	}
+
+	// issues have paths with evaluated symlinks
+	if path, err = fsutils.OverlayPath(path); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.docs[params.TextDocument.URI] = &document{
+		path:    path,
+		content: params.TextDocument.Text,
+		version: params.TextDocument.Version,
+	}
+	s.mu.Unlock()
+
+	s.scheduleLint(params.TextDocument.URI)
+	return nil
+}
+
+func (s *Server) didChange(params *DidChangeTextDocumentParams) error {
+	if len(params.ContentChanges) == 0 {
+		return nil
+	}
+
+	// only full document changes are requested by capabilities
+	change := params.ContentChanges[len(params.ContentChanges)-1]
+	if change.Range != nil {
+		return errors.New("incremental document changes aren't supported")
+	}
+
+	s.mu.Lock()
+	doc := s.docs[params.TextDocument.URI]
+	if doc != nil {
+		doc.content = change.Text
+		if params.TextDocument.Version != nil {
+			doc.version = *params.TextDocument.Version
+		}
+	}
+	s.mu.Unlock()
+
+	if doc == nil {
+		return fmt.Errorf("document %s isn't opened", params.TextDocument.URI)
+	}
+
+	s.scheduleLint(params.TextDocument.URI)
+	return nil
+}
+
+func (s *Server) didSave(params *DidSaveTextDocumentParams) error {
+	if params.Text != nil {
+		s.mu.Lock()
+		if doc := s.docs[params.TextDocument.URI]; doc != nil {
+			doc.content = *params.Text
+		}
+		s.mu.Unlock()
+	}
+
+	s.scheduleLint(params.TextDocument.URI)
+	return nil
+}
+
+func (s *Server) didClose(params *DidCloseTextDocumentParams) error {
+	s.mu.Lock()
+	delete(s.docs, params.TextDocument.URI)
+	delete(s.pending, params.TextDocument.URI)
+	s.mu.Unlock()
+
+	return s.conn.notify("textDocument/publishDiagnostics", PublishDiagnosticsParams{
+		URI:         params.TextDocument.URI,
+		Diagnostics: []Diagnostic{},
+	})
+}
+
+func (s *Server) didChangeWatchedFiles(params *DidChangeWatchedFilesParams) error {
+	for _, change := range params.Changes {
+		path, err := uriToPath(change.URI)
+		if err != nil {
+			return err
+		}
+		if s.isConfigFile(path) {
+			s.notifyConfigChanged(path)
+		}
+	}
+	return nil
+}
+
+// notifyConfigChanged asks the user to restart the server once for every changed config file:
+// an empty config file means changed settings of the client
+func (s *Server) notifyConfigChanged(configFile string) {
+	s.mu.Lock()
+	notified := s.notifiedConfig[configFile]
+	s.notifiedConfig[configFile] = true
+	s.mu.Unlock()
+	if notified {
+		return
+	}
+
+	msg := "Settings were changed: golangci-lint reads options only from its config file, " +
+		"restart the language server to reload it"
+	if configFile != "" {
+		msg = fmt.Sprintf("Config file %s was changed: restart the golangci-lint language server to reload it",
+			configFile)
+	}
+	s.log.Warnf("%s", msg)
+	if err := s.conn.notify("window/showMessage", ShowMessageParams{Type: messageTypeWarning, Message: msg}); err != nil {
+		s.log.Warnf("Can't show message: %s", err)
+	}
+}
+
+func (s *Server) scheduleLint(uri string) {
+	s.mu.Lock()
+	s.pending[uri] = true
+	s.mu.Unlock()
+
+	select {
+	case s.lintReady <- struct{}{}:
+	default: // the linting is already scheduled
+	}
+}
+
+// lintLoop lints pending documents one by one: changes made during a linting
+// are linted once after it, not on every change.
+func (s *Server) lintLoop(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.lintReady:
+		}
+
+		for {
+			uri, ok := s.popPending()
+			if !ok {
+				break
+			}
+			if err := s.lintDocument(ctx, uri); err != nil {
+				s.log.Warnf("Can't lint %s: %s", uri, err)
+			}
+		}
+	}
+}
+
+func (s *Server) popPending() (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	uris := make([]string, 0, len(s.pending))
+	for uri := range s.pending {
+		uris = append(uris, uri)
+	}
+	if len(uris) == 0 {
+		return "", false
+	}
+
+	sort.Strings(uris)
+	delete(s.pending, uris[0])
+	return uris[0], true
+}
+
+func (s *Server) lintDocument(ctx context.Context, uri string) error {
+	s.mu.Lock()
+	doc := s.docs[uri]
+	if doc == nil {
+		s.mu.Unlock()
+		return nil // closed
+	}
+	content, version := doc.content, doc.version
+	overlay := map[string][]byte{}
+	for _, d := range s.docs {
+		overlay[d.path] = []byte(d.content)
+	}
+	s.mu.Unlock()
+
+	issues, err := s.lint(ctx, doc.path, overlay)
+	if err != nil {
+		return err
+	}
+
+	t := newText(content)
+	var docIssues []result.Issue
+	diagnostics := []Diagnostic{}
+	for _, i := range issues {
+		i := s.absIssue(i)
+		if i.FilePath() != doc.path {
+			continue
+		}
+		docIssues = append(docIssues, i)
+		diagnostics = append(diagnostics, issueDiagnostic(t, &i))
+	}
+
+	s.mu.Lock()
+	isOpened := s.docs[uri] == doc
+	doc.issues, doc.text, doc.textVersion = docIssues, t, version
+	s.mu.Unlock()
+
+	if !isOpened { // the document was closed during the linting
+		return nil
+	}
+
+	return s.conn.notify("textDocument/publishDiagnostics", PublishDiagnosticsParams{
+		URI:         uri,
+		Diagnostics: diagnostics,
+	})
+}
+
+func (s *Server) absPath(path string) string {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(s.wd, path)
+	}
+
+	if evaledPath, err := fsutils.EvalSymlinks(path); err == nil {
+		return evaledPath
+	}
+	return path
+}
+
+// absIssue makes paths of the issue absolute
+func (s *Server) absIssue(i result.Issue) result.Issue {
+	i.Pos.Filename = s.absPath(i.Pos.Filename)
+	if i.Replacement != nil && len(i.Replacement.TextEdits) != 0 {
+		r := *i.Replacement
+		r.TextEdits = make([]result.TextEdit, 0, len(i.Replacement.TextEdits))
+		for _, edit := range i.Replacement.TextEdits {
+			edit.Filename = s.absPath(edit.Filename)
+			r.TextEdits = append(r.TextEdits, edit)
+		}
+		i.Replacement = &r
+	}
+	return i
+}
+
+// uriOf returns URI of the opened document with the path or makes a new URI
+func (s *Server) uriOf(path string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for uri, d := range s.docs {
+		if d.path == path {
+			return uri
+		}
+	}
+	return pathToURI(path)
+}
+
+// getText returns text of the opened document or the file on disk
+func (s *Server) getText(path string) (text, error) {
+	s.mu.Lock()
+	for _, d := range s.docs {
+		if d.path == path {
+			s.mu.Unlock()
+			return newText(d.content), nil
+		}
+	}
+	s.mu.Unlock()
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return newText(string(content)), nil
+}
+
+// versionOf returns the version of the opened document or nil
+func (s *Server) versionOf(uri string) *int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if d := s.docs[uri]; d != nil {
+		version := d.version
+		return &version
+	}
+	return nil
+}
+
+// workspaceEdit makes versioned document changes from edits by document URIs: edits of the document
+// are made for its linted text, edits of other files are made for their current text
+func (s *Server) workspaceEdit(uri string, textVersion int, edits map[string][]TextEdit) *WorkspaceEdit {
+	uris := make([]string, 0, len(edits))
+	for editURI := range edits {
+		uris = append(uris, editURI)
+	}
+	sort.Strings(uris)
+
+	ret := &WorkspaceEdit{DocumentChanges: []TextDocumentEdit{}}
+	for _, editURI := range uris {
+		version := s.versionOf(editURI)
+		if editURI == uri {
+			version = &textVersion
+		}
+		ret.DocumentChanges = append(ret.DocumentChanges, TextDocumentEdit{
+			TextDocument: VersionedTextDocumentIdentifier{URI: editURI, Version: version},
+			Edits:        edits[editURI],
+		})
+	}
+	return ret
+}
+
+func (s *Server) codeActions(params *CodeActionParams) ([]CodeAction, error) {
+	s.mu.Lock()
+	doc := s.docs[params.TextDocument.URI]
+	var issues []result.Issue
+	var t text
+	var path string
+	var textVersion int
+	if doc != nil {
+		issues, t, path, textVersion = doc.issues, doc.text, doc.path, doc.textVersion
+	}
+	s.mu.Unlock()
+
+	getText := func(editPath string) (text, error) {
+		if editPath == path {
+			return t, nil // positions of issues are for the linted text
+		}
+		return s.getText(editPath)
+	}
+
+	actions := []CodeAction{}
+	for idx := range issues {
+		i := &issues[idx]
+		diagnostic := issueDiagnostic(t, i)
+		if !rangesOverlap(diagnostic.Range, params.Range) {
+			continue
+		}
+
+		edits, err := replacementEdits(t, params.TextDocument.URI, i, getText, s.uriOf)
+		if err != nil {
+			s.log.Warnf("Can't make fix of issue %q: %s", i.Text, err)
+		} else if len(edits) != 0 {
+			actions = append(actions, CodeAction{
+				Title:       fmt.Sprintf("Fix: %s", i.Text),
+				Kind:        codeActionKindQuickFix,
+				Diagnostics: []Diagnostic{diagnostic},
+				Edit:        s.workspaceEdit(params.TextDocument.URI, textVersion, edits),
+			})
+		}
+
+		if edit := nolintEdit(t, i); edit != nil {
+			actions = append(actions, CodeAction{
+				Title:       fmt.Sprintf("Disable %s for this line", i.FromLinter),
+				Kind:        codeActionKindQuickFix,
+				Diagnostics: []Diagnostic{diagnostic},
+				Edit: s.workspaceEdit(params.TextDocument.URI, textVersion,
+					map[string][]TextEdit{params.TextDocument.URI: {*edit}}),
+			})
+		}
+	}
+
+	return actions, nil
+}
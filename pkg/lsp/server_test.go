@@ -0,0 +1,209 @@
+package lsp
+
+import (
+	"context"
+	"encoding/json"
+	"go/token"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func TestConnFraming(t *testing.T) {
+	r, w := io.Pipe()
+	c := newConn(r, w)
+
+	go func() {
+		params := json.RawMessage(`{"a":1}`)
+		_ = c.write(&message{Method: "m", Params: &params})
+	}()
+
+	msg, err := c.read()
+	require.NoError(t, err)
+	assert.Equal(t, "2.0", msg.JSONRPC)
+	assert.Equal(t, "m", msg.Method)
+	assert.Equal(t, `{"a":1}`, string(*msg.Params))
+}
+
+// client is a test LSP client talking with the server over pipes
+type client struct {
+	t    *testing.T
+	conn *conn
+	id   int
+}
+
+func (c *client) request(method string, params interface{}) *message {
+	c.id++
+	id := json.RawMessage(strings.Repeat("1", c.id))
+	data, err := json.Marshal(params)
+	require.NoError(c.t, err)
+	raw := json.RawMessage(data)
+	require.NoError(c.t, c.conn.write(&message{ID: &id, Method: method, Params: &raw}))
+
+	for {
+		msg := c.read()
+		if msg.ID != nil && string(*msg.ID) == string(id) {
+			return msg
+		}
+	}
+}
+
+func (c *client) read() *message {
+	msg, err := c.conn.read()
+	require.NoError(c.t, err)
+	return msg
+}
+
+func (c *client) expectDiagnostics(uri string) []Diagnostic {
+	for {
+		msg := c.read()
+		if msg.Method != "textDocument/publishDiagnostics" {
+			continue
+		}
+
+		var params PublishDiagnosticsParams
+		require.NoError(c.t, json.Unmarshal(*msg.Params, &params))
+		if params.URI == uri {
+			return params.Diagnostics
+		}
+	}
+}
+
+func (c *client) expectMessage() string {
+	for {
+		msg := c.read()
+		if msg.Method != "window/showMessage" {
+			continue
+		}
+
+		var params ShowMessageParams
+		require.NoError(c.t, json.Unmarshal(*msg.Params, &params))
+		return params.Message
+	}
+}
+
+func TestServer(t *testing.T) {
+	dir, err := ioutil.TempDir("", "golangci_lint_lsp_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	dir, err = filepath.EvalSymlinks(dir)
+	require.NoError(t, err)
+	path := filepath.Join(dir, "a.go")
+	require.NoError(t, ioutil.WriteFile(path, []byte("package p\n"), os.ModePerm))
+	uri := pathToURI(path)
+
+	// the fake linter reports lines with TODO of the linted file from the overlay
+	lint := func(ctx context.Context, lintPath string, overlay map[string][]byte) ([]result.Issue, error) {
+		var issues []result.Issue
+		for n, line := range strings.Split(string(overlay[lintPath]), "\n") {
+			if col := strings.Index(line, "TODO"); col != -1 {
+				issues = append(issues, result.Issue{
+					FromLinter: "godox",
+					Text:       "TODO found",
+					Pos:        token.Position{Filename: "a.go", Line: n + 1, Column: col + 1},
+					Replacement: &result.Replacement{
+						Inline: &result.InlineFix{StartCol: col, Length: len("TODO"), NewString: "DONE"},
+					},
+				})
+			}
+		}
+		return issues, nil
+	}
+
+	serverR, clientW := io.Pipe()
+	clientR, serverW := io.Pipe()
+	c := &client{t: t, conn: newConn(clientR, clientW)}
+
+	type runResult struct {
+		shutdown bool
+		err      error
+	}
+	configPath := filepath.Join(dir, ".golangci.yml")
+	isConfigFile := func(path string) bool {
+		return path == configPath
+	}
+	configChanged := make(chan string, 1)
+	done := make(chan runResult, 1)
+	go func() {
+		server := NewServer(dir, lint, isConfigFile, configChanged, logutils.NewStderrLog("lsp"))
+		shutdown, err := server.Run(context.Background(), serverR, serverW)
+		serverW.Close()
+		done <- runResult{shutdown, err}
+	}()
+
+	resp := c.request("initialize", map[string]interface{}{})
+	require.Nil(t, resp.Error)
+	var initResult InitializeResult
+	data, err := json.Marshal(resp.Result)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &initResult))
+	assert.True(t, initResult.Capabilities.CodeActionProvider)
+	assert.Equal(t, textDocumentSyncKindFull, initResult.Capabilities.TextDocumentSync.Change)
+
+	require.NoError(t, c.conn.notify("textDocument/didOpen", DidOpenTextDocumentParams{
+		TextDocument: TextDocumentItem{URI: uri, Version: 1, Text: "package p\n\n// TODO: x\n"},
+	}))
+	diagnostics := c.expectDiagnostics(uri)
+	require.Len(t, diagnostics, 1)
+	assert.Equal(t, Position{Line: 2, Character: 3}, diagnostics[0].Range.Start)
+	assert.Equal(t, "TODO found", diagnostics[0].Message)
+
+	resp = c.request("textDocument/codeAction", CodeActionParams{
+		TextDocument: TextDocumentIdentifier{URI: uri},
+		Range:        Range{Start: Position{Line: 2, Character: 5}, End: Position{Line: 2, Character: 5}},
+	})
+	require.Nil(t, resp.Error)
+	var actions []CodeAction
+	data, err = json.Marshal(resp.Result)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &actions))
+	require.Len(t, actions, 2)
+	assert.Equal(t, "Fix: TODO found", actions[0].Title)
+	require.Len(t, actions[0].Edit.DocumentChanges, 1)
+	fixChange := actions[0].Edit.DocumentChanges[0]
+	assert.Equal(t, uri, fixChange.TextDocument.URI)
+	require.NotNil(t, fixChange.TextDocument.Version)
+	assert.Equal(t, 1, *fixChange.TextDocument.Version)
+	assert.Equal(t, "DONE", fixChange.Edits[0].NewText)
+	assert.Equal(t, "Disable godox for this line", actions[1].Title)
+	assert.Equal(t, "//nolint:godox ", actions[1].Edit.DocumentChanges[0].Edits[0].NewText)
+
+	var change DidChangeTextDocumentParams
+	require.NoError(t, json.Unmarshal([]byte(`{"contentChanges":[{"text":"package p\n"}]}`), &change))
+	change.TextDocument.URI = uri
+	require.NoError(t, c.conn.notify("textDocument/didChange", change))
+	assert.Empty(t, c.expectDiagnostics(uri))
+
+	require.NoError(t, c.conn.notify("workspace/didChangeWatchedFiles", DidChangeWatchedFilesParams{
+		Changes: []FileEvent{{URI: uri, Type: 2}, {URI: pathToURI(configPath), Type: 2}},
+	}))
+	assert.Contains(t, c.expectMessage(), "Config file "+configPath+" was changed")
+
+	configChanged <- "base.yml"
+	assert.Contains(t, c.expectMessage(), "Config file base.yml was changed")
+
+	require.NoError(t, c.conn.notify("workspace/didChangeConfiguration", map[string]interface{}{}))
+	assert.Contains(t, c.expectMessage(), "restart the language server")
+
+	resp = c.request("unknown/method", nil)
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, codeMethodNotFound, resp.Error.Code)
+
+	resp = c.request("shutdown", nil)
+	require.Nil(t, resp.Error)
+	require.NoError(t, c.conn.notify("exit", nil))
+
+	res := <-done
+	require.NoError(t, res.err)
+	assert.True(t, res.shutdown)
+}
@@ -4,8 +4,8 @@ import (
 	"context"
 	"encoding/xml"
 	"fmt"
+	"io"
 
-	"github.com/golangci/golangci-lint/pkg/logutils"
 	"github.com/golangci/golangci-lint/pkg/result"
 )
 
@@ -30,13 +30,15 @@ type checkstyleError struct {
 
 const defaultSeverity = "error"
 
-type Checkstyle struct{}
+type Checkstyle struct {
+	w io.Writer
+}
 
-func NewCheckstyle() *Checkstyle {
-	return &Checkstyle{}
+func NewCheckstyle(w io.Writer) *Checkstyle {
+	return &Checkstyle{w: w}
 }
 
-func (Checkstyle) Print(ctx context.Context, issues <-chan result.Issue) error {
+func (p Checkstyle) Print(ctx context.Context, issues <-chan result.Issue) error {
 	out := checkstyleOutput{
 		Version: "5.0",
 	}
@@ -53,12 +55,17 @@ func (Checkstyle) Print(ctx context.Context, issues <-chan result.Issue) error {
 			files[issue.FilePath()] = file
 		}
 
+		severity := defaultSeverity
+		if issue.Severity != "" {
+			severity = issue.Severity
+		}
+
 		newError := &checkstyleError{
 			Column:   issue.Column(),
 			Line:     issue.Line(),
 			Message:  issue.Text,
 			Source:   issue.FromLinter,
-			Severity: defaultSeverity,
+			Severity: severity,
 		}
 
 		file.Errors = append(file.Errors, newError)
@@ -74,6 +81,6 @@ func (Checkstyle) Print(ctx context.Context, issues <-chan result.Issue) error {
 		return err
 	}
 
-	fmt.Fprintf(logutils.StdOut, "%s%s\n", xml.Header, data)
+	fmt.Fprintf(p.w, "%s%s\n", xml.Header, data)
 	return nil
 }
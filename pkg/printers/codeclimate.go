@@ -5,43 +5,155 @@ import (
 	"crypto/md5"
 	"encoding/json"
 	"fmt"
+	"io"
+	"strings"
 
-	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/lint/linter"
 	"github.com/golangci/golangci-lint/pkg/result"
 )
 
-// CodeClimateIssue is a subset of the Code Climate spec - https://github.com/codeclimate/spec/blob/master/SPEC.md#data-types
-// It is just enough to support GitLab CI Code Quality - https://docs.gitlab.com/ee/user/project/merge_requests/code_quality.html
+const (
+	codeClimateIssueType       = "issue"
+	codeClimateDefaultSeverity = "major"
+	codeClimateDefaultCategory = "Style"
+)
+
+// CodeClimateIssue is an issue of the Code Climate spec - https://github.com/codeclimate/spec/blob/master/SPEC.md#data-types
+// It's supported by GitLab CI Code Quality - https://docs.gitlab.com/ee/user/project/merge_requests/code_quality.html
 type CodeClimateIssue struct {
+	Type        string `json:"type"`
+	CheckName   string `json:"check_name"`
 	Description string `json:"description"`
-	Fingerprint string `json:"fingerprint"`
+	Content     struct {
+		Body string `json:"body"`
+	} `json:"content"`
+	Categories  []string `json:"categories"`
+	Severity    string   `json:"severity"`
+	Fingerprint string   `json:"fingerprint"`
 	Location    struct {
 		Path  string `json:"path"`
 		Lines struct {
 			Begin int `json:"begin"`
+			End   int `json:"end"`
 		} `json:"lines"`
 	} `json:"location"`
 }
 
+// codeClimatePresetCategories maps linter presets to Code Climate categories
+var codeClimatePresetCategories = map[string]string{
+	linter.PresetBugs:        "Bug Risk",
+	linter.PresetComplexity:  "Complexity",
+	linter.PresetFormatting:  "Style",
+	linter.PresetStyle:       "Style",
+	linter.PresetUnused:      "Clarity",
+	linter.PresetPerformance: "Performance",
+}
+
+// codeClimateLinterCategories are categories of linters which aren't expressed by presets
+var codeClimateLinterCategories = map[string]string{
+	"gosec": "Security",
+	"dupl":  "Duplication",
+}
+
 type CodeClimate struct {
+	linters []*linter.Config
+	w       io.Writer
+}
+
+func NewCodeClimate(linters []*linter.Config, w io.Writer) *CodeClimate {
+	return &CodeClimate{
+		linters: linters,
+		w:       w,
+	}
+}
+
+// codeClimateSeverity maps issue severity to one of Code Climate severities:
+// info, minor, major, critical or blocker. Issues without severity are major:
+// errors are more severe and warnings are less severe than them.
+func codeClimateSeverity(severity string) string {
+	switch strings.ToLower(severity) {
+	case "info", "minor", "major", "critical", "blocker":
+		return strings.ToLower(severity)
+	case "note", "hint", "suggestion":
+		return "info"
+	case "warning", "warn":
+		return "minor"
+	case "error":
+		return "critical"
+	default:
+		return codeClimateDefaultSeverity
+	}
+}
+
+func (p CodeClimate) linterCategories() map[string][]string {
+	ret := map[string][]string{}
+	for _, lc := range p.linters {
+		seen := map[string]bool{}
+		addCategory := func(c string) {
+			if !seen[c] {
+				seen[c] = true
+				ret[lc.Name()] = append(ret[lc.Name()], c)
+			}
+		}
+
+		if c := codeClimateLinterCategories[lc.Name()]; c != "" {
+			addCategory(c)
+		}
+		for _, preset := range lc.InPresets {
+			if c := codeClimatePresetCategories[preset]; c != "" {
+				addCategory(c)
+			}
+		}
+	}
+
+	return ret
+}
+
+// codeClimateFingerprint returns a checksum of the issue: MD5 of the filename,
+// text and the first line of source if it's known. It doesn't depend on the
+// line number to keep the issue identity when code above it changes.
+func codeClimateFingerprint(i *result.Issue) string {
+	data := i.Pos.Filename + i.Text
+	if len(i.SourceLines) != 0 {
+		data += i.SourceLines[0]
+	}
+
+	return fmt.Sprintf("%X", md5.Sum([]byte(data)))
 }
 
-func NewCodeClimate() *CodeClimate {
-	return &CodeClimate{}
+func codeClimateContent(i *result.Issue) string {
+	if len(i.SourceLines) == 0 {
+		return i.Text
+	}
+
+	return fmt.Sprintf("%s\n\n```go\n%s\n```", i.Text, strings.Join(i.SourceLines, "\n"))
 }
 
 func (p CodeClimate) Print(ctx context.Context, issues <-chan result.Issue) error {
+	linterCategories := p.linterCategories()
+
 	allIssues := []CodeClimateIssue{}
 	for i := range issues {
+		i := i
+
 		var issue CodeClimateIssue
+		issue.Type = codeClimateIssueType
+		issue.CheckName = i.FromLinter
 		issue.Description = i.FromLinter + ": " + i.Text
-		issue.Location.Path = i.Pos.Filename
-		issue.Location.Lines.Begin = i.Pos.Line
+		issue.Content.Body = codeClimateContent(&i)
+		issue.Severity = codeClimateSeverity(i.Severity)
+		issue.Fingerprint = codeClimateFingerprint(&i)
+
+		issue.Categories = linterCategories[i.FromLinter]
+		if len(issue.Categories) == 0 {
+			// issues can come from linters which aren't in the enabled set, e.g. from typecheck
+			issue.Categories = []string{codeClimateDefaultCategory}
+		}
 
-		// Need a checksum of the issue, so we use MD5 of the filename, text, and first line of source
-		hash := md5.New()
-		_, _ = hash.Write([]byte(i.Pos.Filename + i.Text + i.SourceLines[0]))
-		issue.Fingerprint = fmt.Sprintf("%X", hash.Sum(nil))
+		lineRange := i.GetLineRange()
+		issue.Location.Path = i.Pos.Filename
+		issue.Location.Lines.Begin = lineRange.From
+		issue.Location.Lines.End = lineRange.To
 
 		allIssues = append(allIssues, issue)
 	}
@@ -51,6 +163,6 @@ func (p CodeClimate) Print(ctx context.Context, issues <-chan result.Issue) erro
 		return err
 	}
 
-	fmt.Fprint(logutils.StdOut, string(outputJSON))
+	fmt.Fprint(p.w, string(outputJSON))
 	return nil
 }
@@ -0,0 +1,72 @@
+package printers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+const githubActionsDefaultCommand = "error"
+
+// GitHubActions prints issues as GitHub Actions workflow commands:
+// they are shown as annotations of pull requests.
+type GitHubActions struct {
+	w io.Writer
+}
+
+func NewGitHubActions(w io.Writer) *GitHubActions {
+	return &GitHubActions{w: w}
+}
+
+// githubActionsCommand maps issue severity to one of workflow commands: error, warning or notice.
+func githubActionsCommand(severity string) string {
+	switch strings.ToLower(severity) {
+	case "error", "warning", "notice":
+		return strings.ToLower(severity)
+	case "warn", "major":
+		return "warning"
+	case "info", "note", "hint", "suggestion", "minor":
+		return "notice"
+	default:
+		return githubActionsDefaultCommand
+	}
+}
+
+// escapeGitHubActionsData escapes a message of a workflow command
+func escapeGitHubActionsData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// escapeGitHubActionsProperty escapes a property value of a workflow command
+func escapeGitHubActionsProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
+
+func formatGitHubActionsIssue(i *result.Issue) string {
+	props := []string{
+		"file=" + escapeGitHubActionsProperty(i.FilePath()),
+		fmt.Sprintf("line=%d", i.Line()),
+	}
+
+	if i.LineRange != nil && i.LineRange.To > i.LineRange.From {
+		props = append(props, fmt.Sprintf("endLine=%d", i.LineRange.To))
+	}
+	if i.Column() != 0 {
+		props = append(props, fmt.Sprintf("col=%d", i.Column()))
+	}
+
+	return fmt.Sprintf("::%s %s::%s", githubActionsCommand(i.Severity), strings.Join(props, ","),
+		escapeGitHubActionsData(fmt.Sprintf("%s: %s", i.FromLinter, i.Text)))
+}
+
+func (p GitHubActions) Print(ctx context.Context, issues <-chan result.Issue) error {
+	for i := range issues {
+		i := i
+		fmt.Fprintln(p.w, formatGitHubActionsIssue(&i))
+	}
+
+	return nil
+}
@@ -0,0 +1,248 @@
+package printers
+
+import (
+	"context"
+	"html/template"
+	"io"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+const htmlTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>golangci-lint report</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 0; color: #24292e; }
+header { background: #24292e; color: #fff; padding: 12px 24px; }
+header h1 { font-size: 20px; margin: 0; }
+main { display: flex; }
+nav { width: 300px; min-width: 300px; padding: 16px 24px; border-right: 1px solid #e1e4e8; }
+nav h2 { font-size: 14px; text-transform: uppercase; color: #586069; }
+nav table { width: 100%; border-collapse: collapse; font-size: 13px; }
+nav td { padding: 2px 4px; cursor: pointer; word-break: break-all; }
+nav td.count { text-align: right; color: #586069; }
+nav tr:hover, nav tr.active { background: #f1f8ff; }
+section { flex: 1; padding: 16px 24px; overflow-x: auto; }
+input[type=search] { width: 100%; padding: 6px 8px; font-size: 14px; box-sizing: border-box; margin-bottom: 16px; }
+.file { margin-bottom: 24px; border: 1px solid #e1e4e8; border-radius: 4px; }
+.file h3 { font-size: 14px; margin: 0; padding: 8px 12px; background: #f6f8fa; border-bottom: 1px solid #e1e4e8; }
+.linter h4 { font-size: 13px; margin: 0; padding: 6px 12px; color: #586069; }
+.issue { padding: 6px 12px; border-top: 1px solid #eaecef; font-size: 14px; }
+.issue .pos { color: #586069; font-family: monospace; margin-right: 8px; }
+.issue .severity { font-size: 12px; padding: 0 6px; border-radius: 8px; background: #e1e4e8; margin-right: 8px; }
+.issue pre { background: #f6f8fa; padding: 6px 8px; margin: 6px 0 0; overflow-x: auto; }
+.hidden { display: none; }
+</style>
+</head>
+<body>
+<header><h1>golangci-lint report: {{.Total}} issues</h1></header>
+<main>
+<nav>
+<h2>Linters</h2>
+<table>
+{{range .Linters}}<tr data-filter="linter" data-value="{{.Name}}"><td>{{.Name}}</td><td class="count">{{.Count}}</td></tr>
+{{end}}</table>
+<h2>Directories</h2>
+<table>
+{{range .Dirs}}<tr data-filter="dir" data-value="{{.Name}}"><td>{{.Name}}</td><td class="count">{{.Count}}</td></tr>
+{{end}}</table>
+</nav>
+<section>
+<input type="search" id="search" placeholder="Search issues by text, file or linter">
+{{range .Files}}<div class="file" data-dir="{{.Dir}}">
+<h3>{{.Path}}</h3>
+{{range .Linters}}<div class="linter" data-linter="{{.Name}}">
+<h4>{{.Name}}</h4>
+{{range .Issues}}<div class="issue" data-search="{{.Search}}">
+<span class="pos">{{.Pos}}</span>{{if .Severity}}<span class="severity">{{.Severity}}</span>{{end}}{{.Text}}
+{{if .Code}}<pre>{{.Code}}</pre>{{end}}
+</div>
+{{end}}</div>
+{{end}}</div>
+{{end}}</section>
+</main>
+<script>
+(function() {
+  var filter = {linter: "", dir: ""};
+  var search = document.getElementById("search");
+
+  function apply() {
+    var query = search.value.toLowerCase();
+    document.querySelectorAll(".file").forEach(function(file) {
+      var fileVisible = false;
+      var dirMatches = !filter.dir || file.dataset.dir === filter.dir;
+      file.querySelectorAll(".linter").forEach(function(linter) {
+        var linterVisible = false;
+        var linterMatches = dirMatches && (!filter.linter || linter.dataset.linter === filter.linter);
+        linter.querySelectorAll(".issue").forEach(function(issue) {
+          var visible = linterMatches && issue.dataset.search.indexOf(query) !== -1;
+          issue.classList.toggle("hidden", !visible);
+          linterVisible = linterVisible || visible;
+        });
+        linter.classList.toggle("hidden", !linterVisible);
+        fileVisible = fileVisible || linterVisible;
+      });
+      file.classList.toggle("hidden", !fileVisible);
+    });
+  }
+
+  document.querySelectorAll("nav tr").forEach(function(row) {
+    row.addEventListener("click", function() {
+      var kind = row.dataset.filter;
+      filter[kind] = filter[kind] === row.dataset.value ? "" : row.dataset.value;
+      document.querySelectorAll("nav tr[data-filter=" + kind + "]").forEach(function(r) {
+        r.classList.toggle("active", r.dataset.value === filter[kind]);
+      });
+      apply();
+    });
+  });
+  search.addEventListener("input", apply);
+})();
+</script>
+</body>
+</html>
+`
+
+type htmlIssue struct {
+	Pos      string // line and column
+	Severity string
+	Text     string
+	Code     string
+	Search   string // lowercased text to search by
+
+	line int
+}
+
+type htmlLinterIssues struct {
+	Name   string
+	Issues []htmlIssue
+}
+
+type htmlFile struct {
+	Path    string
+	Dir     string
+	Linters []*htmlLinterIssues
+}
+
+type htmlCount struct {
+	Name  string
+	Count int
+}
+
+type htmlReport struct {
+	Total   int
+	Files   []*htmlFile
+	Linters []htmlCount
+	Dirs    []htmlCount
+}
+
+// HTML prints issues as a single static HTML page with issues grouped
+// by files and linters, search and filters.
+type HTML struct {
+	w io.Writer
+}
+
+func NewHTML(w io.Writer) *HTML {
+	return &HTML{w: w}
+}
+
+func (p HTML) Print(ctx context.Context, issues <-chan result.Issue) error {
+	var report htmlReport
+	files := map[string]*htmlFile{}
+	linterCounts := map[string]int{}
+	dirCounts := map[string]int{}
+
+	for i := range issues {
+		i := i
+		report.Total++
+		linterCounts[i.FromLinter]++
+
+		file := files[i.FilePath()]
+		if file == nil {
+			file = &htmlFile{
+				Path: i.FilePath(),
+				Dir:  filepath.Dir(i.FilePath()),
+			}
+			files[i.FilePath()] = file
+			report.Files = append(report.Files, file)
+		}
+		dirCounts[file.Dir]++
+
+		var linterIssues *htmlLinterIssues
+		for _, li := range file.Linters {
+			if li.Name == i.FromLinter {
+				linterIssues = li
+				break
+			}
+		}
+		if linterIssues == nil {
+			linterIssues = &htmlLinterIssues{Name: i.FromLinter}
+			file.Linters = append(file.Linters, linterIssues)
+		}
+
+		linterIssues.Issues = append(linterIssues.Issues, newHTMLIssue(&i))
+	}
+
+	sort.Slice(report.Files, func(i, j int) bool {
+		return report.Files[i].Path < report.Files[j].Path
+	})
+	for _, f := range report.Files {
+		sort.Slice(f.Linters, func(i, j int) bool {
+			return f.Linters[i].Name < f.Linters[j].Name
+		})
+		for _, li := range f.Linters {
+			issues := li.Issues
+			sort.SliceStable(issues, func(i, j int) bool {
+				return issues[i].line < issues[j].line
+			})
+		}
+	}
+	report.Linters = sortedHTMLCounts(linterCounts)
+	report.Dirs = sortedHTMLCounts(dirCounts)
+
+	t, err := template.New("report").Parse(htmlTemplate)
+	if err != nil {
+		return err
+	}
+
+	return t.Execute(p.w, report)
+}
+
+func newHTMLIssue(i *result.Issue) htmlIssue {
+	pos := strconv.Itoa(i.Line())
+	if i.Column() != 0 {
+		pos += ":" + strconv.Itoa(i.Column())
+	}
+
+	return htmlIssue{
+		Pos:      pos,
+		Severity: i.Severity,
+		Text:     i.Text,
+		Code:     strings.Join(i.SourceLines, "\n"),
+		Search:   strings.ToLower(strings.Join([]string{i.FilePath(), i.FromLinter, i.Text}, " ")),
+		line:     i.Line(),
+	}
+}
+
+// sortedHTMLCounts sorts counts by descending count and by name
+func sortedHTMLCounts(counts map[string]int) []htmlCount {
+	ret := make([]htmlCount, 0, len(counts))
+	for name, count := range counts {
+		ret = append(ret, htmlCount{Name: name, Count: count})
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Count != ret[j].Count {
+			return ret[i].Count > ret[j].Count
+		}
+		return ret[i].Name < ret[j].Name
+	})
+
+	return ret
+}
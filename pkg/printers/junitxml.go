@@ -3,9 +3,9 @@ package printers
 import (
 	"context"
 	"encoding/xml"
+	"io"
 	"strings"
 
-	"github.com/golangci/golangci-lint/pkg/logutils"
 	"github.com/golangci/golangci-lint/pkg/result"
 )
 
@@ -28,17 +28,19 @@ type testCaseXML struct {
 
 type failureXML struct {
 	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
 	Content string `xml:",cdata"`
 }
 
 type JunitXML struct {
+	w io.Writer
 }
 
-func NewJunitXML() *JunitXML {
-	return &JunitXML{}
+func NewJunitXML(w io.Writer) *JunitXML {
+	return &JunitXML{w: w}
 }
 
-func (JunitXML) Print(ctx context.Context, issues <-chan result.Issue) error {
+func (p JunitXML) Print(ctx context.Context, issues <-chan result.Issue) error {
 	suites := make(map[string]testSuiteXML) // use a map to group by file
 
 	for i := range issues {
@@ -51,6 +53,7 @@ func (JunitXML) Print(ctx context.Context, issues <-chan result.Issue) error {
 			ClassName: i.Pos.String(),
 			Failure: failureXML{
 				Message: i.Text,
+				Type:    i.Severity,
 				Content: strings.Join(i.SourceLines, "\n"),
 			},
 		}
@@ -64,7 +67,7 @@ func (JunitXML) Print(ctx context.Context, issues <-chan result.Issue) error {
 		res.TestSuites = append(res.TestSuites, val)
 	}
 
-	enc := xml.NewEncoder(logutils.StdOut)
+	enc := xml.NewEncoder(p.w)
 	enc.Indent("", "  ")
 	if err := enc.Encode(res); err != nil {
 		return err
@@ -0,0 +1,186 @@
+package printers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/golangci/golangci-lint/pkg/lint/linter"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+// SARIF format is described at https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+const (
+	sarifVersion   = "2.1.0"
+	sarifSchemaURI = "https://json.schemastore.org/sarif-2.1.0.json"
+
+	sarifToolName = "golangci-lint"
+	sarifToolURI  = "https://github.com/golangci/golangci-lint"
+
+	sarifDefaultLevel = "error"
+)
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+	HelpURI          string       `json:"helpUri,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+	EndLine     int `json:"endLine,omitempty"`
+}
+
+// sarifLevel maps issue severity to one of SARIF levels: none, note, warning or error.
+func sarifLevel(severity string) string {
+	switch strings.ToLower(severity) {
+	case "none", "note", "warning", "error":
+		return strings.ToLower(severity)
+	case "info", "hint", "suggestion", "minor":
+		return "note"
+	case "warn", "major":
+		return "warning"
+	default:
+		return sarifDefaultLevel
+	}
+}
+
+type SARIF struct {
+	linters []*linter.Config
+	w       io.Writer
+}
+
+func NewSARIF(linters []*linter.Config, w io.Writer) *SARIF {
+	return &SARIF{
+		linters: linters,
+		w:       w,
+	}
+}
+
+func (p SARIF) Print(ctx context.Context, issues <-chan result.Issue) error {
+	run := sarifRun{
+		Tool: sarifTool{
+			Driver: sarifDriver{
+				Name:           sarifToolName,
+				InformationURI: sarifToolURI,
+				Rules:          []sarifRule{},
+			},
+		},
+		Results: []sarifResult{},
+	}
+
+	ruleIndexes := map[string]int{}
+	addRule := func(rule sarifRule) int {
+		if idx, ok := ruleIndexes[rule.ID]; ok {
+			return idx
+		}
+
+		idx := len(run.Tool.Driver.Rules)
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, rule)
+		ruleIndexes[rule.ID] = idx
+		return idx
+	}
+
+	for _, lc := range p.linters {
+		addRule(sarifRule{
+			ID:               lc.Name(),
+			ShortDescription: sarifMessage{Text: lc.Linter.Desc()},
+			HelpURI:          lc.OriginalURL,
+		})
+	}
+
+	for i := range issues {
+		// issues can come from linters which aren't in the enabled set, e.g. from typecheck
+		ruleIndex := addRule(sarifRule{
+			ID:               i.FromLinter,
+			ShortDescription: sarifMessage{Text: i.FromLinter},
+		})
+
+		region := sarifRegion{
+			StartLine:   i.Line(),
+			StartColumn: i.Column(),
+		}
+		if lineRange := i.GetLineRange(); lineRange.To > lineRange.From {
+			region.EndLine = lineRange.To
+		}
+
+		run.Results = append(run.Results, sarifResult{
+			RuleID:    i.FromLinter,
+			RuleIndex: ruleIndex,
+			Level:     sarifLevel(i.Severity),
+			Message:   sarifMessage{Text: i.Text},
+			Locations: []sarifLocation{
+				{
+					PhysicalLocation: sarifPhysicalLocation{
+						ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(i.FilePath())},
+						Region:           region,
+					},
+				},
+			},
+		})
+	}
+
+	out := sarifLog{
+		Version: sarifVersion,
+		Schema:  sarifSchemaURI,
+		Runs:    []sarifRun{run},
+	}
+
+	outputJSON, err := json.Marshal(out)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprint(p.w, string(outputJSON))
+	return nil
+}
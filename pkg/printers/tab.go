@@ -15,12 +15,14 @@ import (
 type Tab struct {
 	printLinterName bool
 	log             logutils.Log
+	w               io.Writer
 }
 
-func NewTab(printLinterName bool, log logutils.Log) *Tab {
+func NewTab(printLinterName bool, log logutils.Log, w io.Writer) *Tab {
 	return &Tab{
 		printLinterName: printLinterName,
 		log:             log,
+		w:               w,
 	}
 }
 
@@ -30,7 +32,7 @@ func (p Tab) SprintfColored(ca color.Attribute, format string, args ...interface
 }
 
 func (p *Tab) Print(ctx context.Context, issues <-chan result.Issue) error {
-	w := tabwriter.NewWriter(logutils.StdOut, 0, 0, 2, ' ', 0)
+	w := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
 
 	for i := range issues {
 		i := i
@@ -45,7 +47,7 @@ func (p *Tab) Print(ctx context.Context, issues <-chan result.Issue) error {
 }
 
 func (p Tab) printIssue(i *result.Issue, w io.Writer) {
-	text := p.SprintfColored(color.FgRed, "%s", i.Text)
+	text := p.SprintfColored(severityColor(i.Severity), "%s", i.Text)
 	if p.printLinterName {
 		text = fmt.Sprintf("%s\t%s", i.FromLinter, text)
 	}
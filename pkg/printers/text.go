@@ -3,6 +3,8 @@ package printers
 import (
 	"context"
 	"fmt"
+	"io"
+	"strings"
 
 	"github.com/fatih/color"
 
@@ -16,14 +18,16 @@ type Text struct {
 	printLinterName bool
 
 	log logutils.Log
+	w   io.Writer
 }
 
-func NewText(printIssuedLine, useColors, printLinterName bool, log logutils.Log) *Text {
+func NewText(printIssuedLine, useColors, printLinterName bool, log logutils.Log, w io.Writer) *Text {
 	return &Text{
 		printIssuedLine: printIssuedLine,
 		useColors:       useColors,
 		printLinterName: printLinterName,
 		log:             log,
+		w:               w,
 	}
 }
 
@@ -53,7 +57,7 @@ func (p *Text) Print(ctx context.Context, issues <-chan result.Issue) error {
 }
 
 func (p Text) printIssue(i *result.Issue) {
-	text := p.SprintfColored(color.FgRed, "%s", i.Text)
+	text := p.SprintfColored(severityColor(i.Severity), "%s", i.Text)
 	if p.printLinterName {
 		text += fmt.Sprintf(" (%s)", i.FromLinter)
 	}
@@ -61,12 +65,25 @@ func (p Text) printIssue(i *result.Issue) {
 	if i.Pos.Column != 0 {
 		pos += fmt.Sprintf(":%d", i.Pos.Column)
 	}
-	fmt.Fprintf(logutils.StdOut, "%s: %s\n", pos, text)
+	fmt.Fprintf(p.w, "%s: %s\n", pos, text)
+}
+
+// severityColor returns a color for the issue text: unknown
+// and empty severities are shown like errors.
+func severityColor(severity string) color.Attribute {
+	switch strings.ToLower(severity) {
+	case "warning", "warn", "minor", "major":
+		return color.FgYellow
+	case "info", "note", "hint", "suggestion":
+		return color.FgCyan
+	default:
+		return color.FgRed
+	}
 }
 
 func (p Text) printSourceCode(i *result.Issue) {
 	for _, line := range i.SourceLines {
-		fmt.Fprintln(logutils.StdOut, line)
+		fmt.Fprintln(p.w, line)
 	}
 }
 
@@ -87,5 +104,5 @@ func (p Text) printUnderLinePointer(i *result.Issue) {
 		}
 	}
 
-	fmt.Fprintf(logutils.StdOut, "%s%s\n", string(prefixRunes), p.SprintfColored(color.FgYellow, "^"))
+	fmt.Fprintf(p.w, "%s%s\n", string(prefixRunes), p.SprintfColored(color.FgYellow, "^"))
 }
@@ -10,6 +10,14 @@ type Replacement struct {
 	NeedOnlyDelete bool     // need to delete all lines of the issue without replacement with new lines
 	NewLines       []string // is NeedDelete is false it's the replacement lines
 	Inline         *InlineFix
+	TextEdits      []TextEdit // byte edits, possibly in multiple files, e.g. from go/analysis suggested fixes
+}
+
+type TextEdit struct {
+	Filename  string
+	Offset    int // zero-based byte offset of the start of the replaced chunk
+	EndOffset int // zero-based byte offset of the end of the replaced chunk, equal to Offset for insertions
+	NewText   string
 }
 
 type InlineFix struct {
@@ -21,7 +29,10 @@ type InlineFix struct {
 type Issue struct {
 	FromLinter string
 	Text       string
-	Pos        token.Position
+
+	Severity string
+
+	Pos token.Position
 
 	LineRange *Range `json:",omitempty"`
 
@@ -33,6 +44,9 @@ type Issue struct {
 
 	// If we know how to fix the issue we can provide replacement lines
 	Replacement *Replacement
+
+	// BuildCombinations are names of combinations of run.build-matrix the issue was found in
+	BuildCombinations []string `json:",omitempty"`
 }
 
 func (i *Issue) FilePath() string {
@@ -0,0 +1,85 @@
+package processors
+
+import (
+	"regexp"
+
+	"github.com/golangci/golangci-lint/pkg/fsutils"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+type BaseRule struct {
+	Text    string
+	Source  string
+	Path    string
+	Linters []string
+}
+
+type baseRule struct {
+	text    *regexp.Regexp
+	source  *regexp.Regexp
+	path    *regexp.Regexp
+	linters []string
+}
+
+func newBaseRule(rule *BaseRule) baseRule {
+	parsedRule := baseRule{
+		linters: rule.Linters,
+	}
+	if rule.Text != "" {
+		parsedRule.text = regexp.MustCompile("(?i)" + rule.Text)
+	}
+	if rule.Source != "" {
+		parsedRule.source = regexp.MustCompile("(?i)" + rule.Source)
+	}
+	if rule.Path != "" {
+		parsedRule.path = regexp.MustCompile(rule.Path)
+	}
+	return parsedRule
+}
+
+func (r *baseRule) isEmpty() bool {
+	return r.text == nil && r.source == nil && r.path == nil && len(r.linters) == 0
+}
+
+func (r *baseRule) matchLinter(i *result.Issue) bool {
+	for _, linter := range r.linters {
+		if linter == i.FromLinter {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *baseRule) matchSource(i *result.Issue, lineCache *fsutils.LineCache, log logutils.Log) bool { //nolint:interfacer
+	sourceLine, err := lineCache.GetLine(i.FilePath(), i.Line())
+	if err != nil {
+		log.Warnf("Failed to get line %s:%d from line cache: %s", i.FilePath(), i.Line(), err)
+		return false // can't properly match
+	}
+
+	return r.source.MatchString(sourceLine)
+}
+
+func (r *baseRule) match(i *result.Issue, lineCache *fsutils.LineCache, log logutils.Log) bool {
+	if r.isEmpty() {
+		return false
+	}
+	if r.text != nil && !r.text.MatchString(i.Text) {
+		return false
+	}
+	if r.path != nil && !r.path.MatchString(i.FilePath()) {
+		return false
+	}
+	if len(r.linters) != 0 && !r.matchLinter(i) {
+		return false
+	}
+
+	// the most heavyweight checking last
+	if r.source != nil && !r.matchSource(i, lineCache, log) {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,248 @@
+package processors
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/golangci/golangci-lint/pkg/fsutils"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+const baselineVersion = 1
+
+type BaselineEntry struct {
+	FromLinter  string
+	Path        string
+	Text        string
+	Fingerprint string
+}
+
+type BaselineFile struct {
+	Version int
+	Issues  []BaselineEntry
+}
+
+var (
+	baselineSpacesRe    = regexp.MustCompile(`\s+`)
+	baselinePositionsRe = regexp.MustCompile(`\.go:\d+(:\d+)?`)
+)
+
+// Baseline hides issues recorded in a baseline file and records current issues
+// into a new baseline file. Issues are matched by fingerprints: they don't
+// depend on line numbers, only on a linter, a file, a normalized issue text
+// and the source code of issue lines.
+type Baseline struct {
+	readPath  string
+	writePath string
+	lineCache *fsutils.LineCache
+	log       logutils.Log
+
+	known        map[string][]BaselineEntry // fingerprint -> not yet matched entries
+	knownCount   int
+	matchedN     int
+	writeEntries []BaselineEntry
+
+	lintedLinters map[string]bool
+	lintedFiles   map[string]bool
+	failedLinters map[string]bool
+}
+
+var (
+	_ LintedRecorder        = &Baseline{}
+	_ FailedLintersRecorder = &Baseline{}
+)
+
+func NewBaseline(readPath, writePath string, lineCache *fsutils.LineCache, log logutils.Log) (*Baseline, error) {
+	p := &Baseline{
+		readPath:  readPath,
+		writePath: writePath,
+		lineCache: lineCache,
+		log:       log,
+		known:     map[string][]BaselineEntry{},
+
+		lintedLinters: map[string]bool{},
+		lintedFiles:   map[string]bool{},
+		failedLinters: map[string]bool{},
+	}
+
+	if readPath == "" {
+		return p, nil
+	}
+
+	data, err := ioutil.ReadFile(readPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "can't read baseline file %s", readPath)
+	}
+
+	var bf BaselineFile
+	if err = json.Unmarshal(data, &bf); err != nil {
+		return nil, errors.Wrapf(err, "can't parse baseline file %s", readPath)
+	}
+	if bf.Version != baselineVersion {
+		return nil, fmt.Errorf("unsupported baseline file %s version %d, expected %d",
+			readPath, bf.Version, baselineVersion)
+	}
+
+	for _, e := range bf.Issues {
+		p.known[e.Fingerprint] = append(p.known[e.Fingerprint], e)
+	}
+	p.knownCount = len(bf.Issues)
+
+	return p, nil
+}
+
+func (p Baseline) Name() string {
+	return "baseline"
+}
+
+func (p *Baseline) Process(issues []result.Issue) ([]result.Issue, error) {
+	if p.readPath == "" && p.writePath == "" {
+		return issues, nil
+	}
+
+	return filterIssues(issues, p.shouldPassIssue), nil
+}
+
+func (p *Baseline) shouldPassIssue(i *result.Issue) bool {
+	fingerprint := p.fingerprint(i)
+
+	if p.writePath != "" {
+		p.writeEntries = append(p.writeEntries, BaselineEntry{
+			FromLinter:  i.FromLinter,
+			Path:        i.FilePath(),
+			Text:        i.Text,
+			Fingerprint: fingerprint,
+		})
+	}
+
+	// every baseline entry can hide only one issue: if the same issue was
+	// copy-pasted it must be shown
+	if entries := p.known[fingerprint]; len(entries) != 0 {
+		p.known[fingerprint] = entries[1:]
+		p.matchedN++
+		return false
+	}
+
+	// all issues are recorded into the new baseline: don't show them
+	return p.writePath == ""
+}
+
+func (p *Baseline) RecordLinted(linters, files []string) {
+	for _, name := range linters {
+		p.lintedLinters[name] = true
+	}
+	for _, f := range files {
+		p.lintedFiles[prettifyPath(f)] = true // entries have paths of prettified issues
+	}
+}
+
+func (p *Baseline) RecordFailedLinter(name string) {
+	p.failedLinters[name] = true
+}
+
+func (p *Baseline) fingerprint(i *result.Issue) string {
+	text := baselinePositionsRe.ReplaceAllString(i.Text, ".go")
+	text = baselineSpacesRe.ReplaceAllString(strings.TrimSpace(text), " ")
+
+	parts := []string{i.FromLinter, i.FilePath(), text}
+
+	lineRange := i.GetLineRange()
+	for line := lineRange.From; line <= lineRange.To; line++ {
+		sourceLine, err := p.lineCache.GetLine(i.FilePath(), line)
+		if err != nil {
+			p.log.Warnf("Failed to get line %s:%d from line cache: %s", i.FilePath(), line, err)
+			break
+		}
+		parts = append(parts, strings.TrimSpace(sourceLine))
+	}
+
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(strings.Join(parts, "\n"))))
+}
+
+func (p Baseline) Finish() {
+	if p.readPath != "" {
+		p.reportFixed()
+	}
+
+	if p.writePath != "" {
+		if err := p.write(); err != nil {
+			p.log.Errorf("Failed to write baseline: %s", err)
+			return
+		}
+		p.log.Infof("Wrote %d issues to baseline file %s", len(p.writeEntries), p.writePath)
+	}
+}
+
+func (p Baseline) reportFixed() {
+	if p.matchedN != 0 {
+		p.log.Infof("%d/%d issues from baseline file %s are still present", p.matchedN, p.knownCount, p.readPath)
+	}
+
+	fixed, notLintedN := p.fixedEntries()
+	if notLintedN != 0 {
+		p.log.Infof("%d issues from baseline file %s aren't checked: their files or linters weren't linted",
+			notLintedN, p.readPath)
+	}
+	if len(fixed) == 0 {
+		return
+	}
+
+	p.log.Warnf("%d issues from baseline file %s are fixed, regenerate it by --write-baseline to shrink it: %s",
+		len(fixed), p.readPath, strings.Join(fixed, "; "))
+}
+
+// fixedEntries returns sorted not matched entries of linted files and linters finished without errors
+// and the count of not matched entries which weren't linted, e.g. only some directories are linted
+func (p Baseline) fixedEntries() (fixed []string, notLintedN int) {
+	for _, entries := range p.known {
+		for _, e := range entries {
+			if !p.lintedFiles[e.Path] || !p.lintedLinters[e.FromLinter] || p.failedLinters[e.FromLinter] {
+				notLintedN++
+				continue
+			}
+			fixed = append(fixed, fmt.Sprintf("%s: %s (%s)", e.Path, e.Text, e.FromLinter))
+		}
+	}
+	sort.Strings(fixed)
+	return fixed, notLintedN
+}
+
+func (p Baseline) write() error {
+	entries := append([]BaselineEntry{}, p.writeEntries...)
+	// sort to get minimal diffs on the baseline file regeneration
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		if a.FromLinter != b.FromLinter {
+			return a.FromLinter < b.FromLinter
+		}
+		if a.Text != b.Text {
+			return a.Text < b.Text
+		}
+		return a.Fingerprint < b.Fingerprint
+	})
+
+	data, err := json.MarshalIndent(BaselineFile{
+		Version: baselineVersion,
+		Issues:  entries,
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err = ioutil.WriteFile(p.writePath, append(data, '\n'), 0644); err != nil {
+		return errors.Wrapf(err, "can't write baseline file %s", p.writePath)
+	}
+
+	return nil
+}
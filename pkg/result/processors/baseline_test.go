@@ -0,0 +1,90 @@
+package processors
+
+import (
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/golangci/golangci-lint/pkg/fsutils"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func newBaselineIssue(filePath string, line int, fromLinter, text string) result.Issue {
+	return result.Issue{
+		FromLinter: fromLinter,
+		Text:       text,
+		Pos: token.Position{
+			Filename: filePath,
+			Line:     line,
+		},
+	}
+}
+
+func TestBaseline(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "baseline")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	baselinePath := filepath.Join(tmpDir, "baseline.json")
+	filePath := filepath.Join(tmpDir, "p.go")
+	log := logutils.NewStderrLog("")
+
+	const origSource = "package p\n\nvar A = 1\n\n// TODO: fix it\n"
+	require.NoError(t, ioutil.WriteFile(filePath, []byte(origSource), os.ModePerm))
+	issuePath := prettifyPath(filePath) // issues are prettified before the baseline processor
+
+	w, err := NewBaseline("", baselinePath, fsutils.NewLineCache(fsutils.NewFileCache()), log)
+	require.NoError(t, err)
+	processAssertEmpty(t, w,
+		newBaselineIssue(issuePath, 3, "golint", "exported var A should have comment or be unexported"),
+		newBaselineIssue(issuePath, 5, "godox", "Line contains TODO/BUG/FIXME: \"TODO: fix it\""),
+	)
+	w.Finish()
+
+	// lines are shifted and the same issue was copy-pasted
+	const newSource = "package p\n\nimport \"fmt\"\n\nvar A = 1\n\n// TODO: fix it\n// TODO: fix it\n"
+	require.NoError(t, ioutil.WriteFile(filePath, []byte(newSource), os.ModePerm))
+
+	r, err := NewBaseline(baselinePath, "", fsutils.NewLineCache(fsutils.NewFileCache()), log)
+	require.NoError(t, err)
+
+	processAssertEmpty(t, r, newBaselineIssue(issuePath, 7, "godox", "Line contains TODO/BUG/FIXME: \"TODO: fix it\""))
+	// only one issue is hidden by one baseline entry
+	processAssertSame(t, r, newBaselineIssue(issuePath, 8, "godox", "Line contains TODO/BUG/FIXME: \"TODO: fix it\""))
+	// the same source line but another linter
+	processAssertSame(t, r, newBaselineIssue(issuePath, 5, "gochecknoglobals", "`A` is a global variable"))
+	processAssertSame(t, r, newBaselineIssue(issuePath, 3, "golint", "exported var A should have comment or be unexported"))
+
+	assert.Equal(t, 1, r.matchedN)
+	assert.Len(t, r.known[r.fingerprint(&result.Issue{
+		FromLinter: "golint",
+		Text:       "exported var A should have comment or be unexported",
+		Pos:        token.Position{Filename: issuePath, Line: 5},
+	})], 1)
+
+	// only entries of linted files and linters finished without errors are fixed
+	fixed, notLintedN := r.fixedEntries()
+	assert.Empty(t, fixed)
+	assert.Equal(t, 1, notLintedN)
+
+	r.RecordLinted([]string{"godox", "golint"}, []string{filePath})
+	fixed, _ = r.fixedEntries()
+	assert.Equal(t, []string{issuePath + ": exported var A should have comment or be unexported (golint)"}, fixed)
+
+	r.RecordFailedLinter("golint")
+	fixed, notLintedN = r.fixedEntries()
+	assert.Empty(t, fixed)
+	assert.Equal(t, 1, notLintedN)
+}
+
+func TestBaselineNotConfigured(t *testing.T) {
+	p, err := NewBaseline("", "", nil, nil)
+	require.NoError(t, err)
+	processAssertSame(t, p, newTextIssue("test"))
+}
@@ -1,8 +1,6 @@
 package processors
 
 import (
-	"regexp"
-
 	"github.com/golangci/golangci-lint/pkg/logutils"
 
 	"github.com/golangci/golangci-lint/pkg/fsutils"
@@ -11,21 +9,11 @@ import (
 )
 
 type excludeRule struct {
-	text    *regexp.Regexp
-	source  *regexp.Regexp
-	path    *regexp.Regexp
-	linters []string
-}
-
-func (r *excludeRule) isEmpty() bool {
-	return r.text == nil && r.path == nil && len(r.linters) == 0
+	baseRule
 }
 
 type ExcludeRule struct {
-	Text    string
-	Source  string
-	Path    string
-	Linters []string
+	BaseRule
 }
 
 type ExcludeRules struct {
@@ -41,19 +29,10 @@ func NewExcludeRules(rules []ExcludeRule, lineCache *fsutils.LineCache, log logu
 	}
 
 	for _, rule := range rules {
-		parsedRule := excludeRule{
-			linters: rule.Linters,
-		}
-		if rule.Text != "" {
-			parsedRule.text = regexp.MustCompile("(?i)" + rule.Text)
-		}
-		if rule.Source != "" {
-			parsedRule.source = regexp.MustCompile("(?i)" + rule.Source)
-		}
-		if rule.Path != "" {
-			parsedRule.path = regexp.MustCompile(rule.Path)
-		}
-		r.rules = append(r.rules, parsedRule)
+		rule := rule
+		r.rules = append(r.rules, excludeRule{
+			baseRule: newBaseRule(&rule.BaseRule),
+		})
 	}
 
 	return r
@@ -66,7 +45,7 @@ func (p ExcludeRules) Process(issues []result.Issue) ([]result.Issue, error) {
 	return filterIssues(issues, func(i *result.Issue) bool {
 		for _, rule := range p.rules {
 			rule := rule
-			if p.match(i, &rule) {
+			if rule.match(i, p.lineCache, p.log) {
 				return false
 			}
 		}
@@ -74,48 +53,6 @@ func (p ExcludeRules) Process(issues []result.Issue) ([]result.Issue, error) {
 	}), nil
 }
 
-func (p ExcludeRules) matchLinter(i *result.Issue, r *excludeRule) bool {
-	for _, linter := range r.linters {
-		if linter == i.FromLinter {
-			return true
-		}
-	}
-
-	return false
-}
-
-func (p ExcludeRules) matchSource(i *result.Issue, r *excludeRule) bool { //nolint:interfacer
-	sourceLine, err := p.lineCache.GetLine(i.FilePath(), i.Line())
-	if err != nil {
-		p.log.Warnf("Failed to get line %s:%d from line cache: %s", i.FilePath(), i.Line(), err)
-		return false // can't properly match
-	}
-
-	return r.source.MatchString(sourceLine)
-}
-
-func (p ExcludeRules) match(i *result.Issue, r *excludeRule) bool {
-	if r.isEmpty() {
-		return false
-	}
-	if r.text != nil && !r.text.MatchString(i.Text) {
-		return false
-	}
-	if r.path != nil && !r.path.MatchString(i.FilePath()) {
-		return false
-	}
-	if len(r.linters) != 0 && !p.matchLinter(i, r) {
-		return false
-	}
-
-	// the most heavyweight checking last
-	if r.source != nil && !p.matchSource(i, r) {
-		return false
-	}
-
-	return true
-}
-
 func (ExcludeRules) Name() string { return "exclude-rules" }
 func (ExcludeRules) Finish()      {}
 
@@ -16,20 +16,28 @@ func TestExcludeRulesMultiple(t *testing.T) {
 	lineCache := fsutils.NewLineCache(fsutils.NewFileCache())
 	p := NewExcludeRules([]ExcludeRule{
 		{
-			Text:    "^exclude$",
-			Linters: []string{"linter"},
+			BaseRule: BaseRule{
+				Text:    "^exclude$",
+				Linters: []string{"linter"},
+			},
 		},
 		{
-			Linters: []string{"testlinter"},
-			Path:    `_test\.go`,
+			BaseRule: BaseRule{
+				Linters: []string{"testlinter"},
+				Path:    `_test\.go`,
+			},
 		},
 		{
-			Text: "^testonly$",
-			Path: `_test\.go`,
+			BaseRule: BaseRule{
+				Text: "^testonly$",
+				Path: `_test\.go`,
+			},
 		},
 		{
-			Source:  "^//go:generate ",
-			Linters: []string{"lll"},
+			BaseRule: BaseRule{
+				Source:  "^//go:generate ",
+				Linters: []string{"lll"},
+			},
 		},
 	}, lineCache, nil)
 	type issueCase struct {
@@ -80,9 +88,11 @@ func TestExcludeRulesMultiple(t *testing.T) {
 func TestExcludeRulesText(t *testing.T) {
 	p := NewExcludeRules([]ExcludeRule{
 		{
-			Text: "^exclude$",
-			Linters: []string{
-				"linter",
+			BaseRule: BaseRule{
+				Text: "^exclude$",
+				Linters: []string{
+					"linter",
+				},
 			},
 		},
 	}, nil, nil)
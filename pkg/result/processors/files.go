@@ -0,0 +1,62 @@
+package processors
+
+import (
+	"path/filepath"
+
+	"github.com/golangci/golangci-lint/pkg/fsutils"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+// Files passes only issues from the given files: it's used
+// when whole packages are loaded to lint only some of their files.
+type Files struct {
+	files map[string]bool // normalized absolute file paths
+	log   logutils.Log
+}
+
+var _ Processor = &Files{}
+
+func NewFiles(files []string, log logutils.Log) *Files {
+	p := &Files{
+		files: map[string]bool{},
+		log:   log,
+	}
+	for _, f := range files {
+		p.files[p.normalizePath(f)] = true
+	}
+
+	return p
+}
+
+func (p Files) Name() string {
+	return "files"
+}
+
+func (p Files) normalizePath(path string) string {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		p.log.Warnf("Can't abs-ify path %s: %s", path, err)
+		return path
+	}
+
+	ret, err := fsutils.EvalSymlinks(absPath)
+	if err != nil {
+		// e.g. a file from stdin which doesn't exist on disk
+		return absPath
+	}
+
+	return ret
+}
+
+func (p Files) Process(issues []result.Issue) ([]result.Issue, error) {
+	if len(p.files) == 0 {
+		return issues, nil
+	}
+
+	return filterIssues(issues, func(i *result.Issue) bool {
+		return p.files[p.normalizePath(i.FilePath())]
+	}), nil
+}
+
+func (p Files) Finish() {}
@@ -0,0 +1,23 @@
+package processors
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/golangci/golangci-lint/pkg/logutils"
+)
+
+func TestFiles(t *testing.T) {
+	log := logutils.NewStderrLog("files")
+
+	processAssertSame(t, NewFiles(nil, log), newFileIssue("any.go"))
+
+	absPath, err := filepath.Abs("a/b.go")
+	require.NoError(t, err)
+
+	p := NewFiles([]string{"a/b.go"}, log)
+	processAssertSame(t, p, newFileIssue("a/b.go"), newFileIssue("./a/b.go"), newFileIssue(absPath))
+	processAssertEmpty(t, p, newFileIssue("a/c.go"), newFileIssue("b.go"))
+}
@@ -3,6 +3,8 @@ package processors
 import (
 	"bytes"
 	"fmt"
+	"go/token"
+	"io"
 	"os"
 	"path/filepath"
 	"sort"
@@ -15,6 +17,7 @@ import (
 	"github.com/golangci/golangci-lint/pkg/logutils"
 
 	"github.com/pkg/errors"
+	"github.com/pmezard/go-difflib/difflib"
 
 	"github.com/golangci/golangci-lint/pkg/config"
 	"github.com/golangci/golangci-lint/pkg/result"
@@ -25,6 +28,7 @@ type Fixer struct {
 	log       logutils.Log
 	fileCache *fsutils.FileCache
 	sw        *timeutils.Stopwatch
+	diff      bytes.Buffer
 }
 
 func NewFixer(cfg *config.Config, log logutils.Log, fileCache *fsutils.FileCache) *Fixer {
@@ -40,7 +44,14 @@ func (f Fixer) printStat() {
 	f.sw.PrintStages()
 }
 
-func (f Fixer) Process(issues <-chan result.Issue) <-chan result.Issue {
+// Diff returns a unified diff of fixes if they weren't applied because of
+// the fix-diff option. It must be called only after reading of all issues
+// from the Process result.
+func (f *Fixer) Diff() string {
+	return f.diff.String()
+}
+
+func (f *Fixer) Process(issues <-chan result.Issue) <-chan result.Issue {
 	if !f.cfg.Issues.NeedFix {
 		return issues
 	}
@@ -48,29 +59,44 @@ func (f Fixer) Process(issues <-chan result.Issue) <-chan result.Issue {
 	outCh := make(chan result.Issue, 1024)
 
 	go func() {
-		issuesToFixPerFile := map[string][]result.Issue{}
+		var fixes []result.Issue // issues with replacements, fix indexes of issues to fix point to them
+		issuesToFixPerFile := map[string][]fixIssue{}
 		for issue := range issues {
 			if issue.Replacement == nil {
 				outCh <- issue
 				continue
 			}
 
-			issuesToFixPerFile[issue.FilePath()] = append(issuesToFixPerFile[issue.FilePath()], issue)
-		}
-
-		for file, issuesToFix := range issuesToFixPerFile {
-			var err error
-			f.sw.TrackStage("all", func() {
-				err = f.fixIssuesInFile(file, issuesToFix) //nolint:scopelint
-			})
-			if err != nil {
-				f.log.Errorf("Failed to fix issues in file %s: %s", file, err)
-
-				// show issues only if can't fix them
-				for _, issue := range issuesToFix {
+			fix := len(fixes)
+			if len(issue.Replacement.TextEdits) != 0 {
+				editIssues, err := f.textEditsToIssues(&issue) //nolint:scopelint
+				if err != nil {
+					f.log.Warnf("Failed to convert text edits of issue %#v: %s", issue, err)
 					outCh <- issue
+					continue
+				}
+
+				fixes = append(fixes, issue)
+				for _, editIssue := range editIssues {
+					file := editIssue.FilePath()
+					issuesToFixPerFile[file] = append(issuesToFixPerFile[file], fixIssue{issue: editIssue, fixes: []int{fix}})
 				}
+				continue
 			}
+
+			fixes = append(fixes, issue)
+			file := issue.FilePath()
+			issuesToFixPerFile[file] = append(issuesToFixPerFile[file], fixIssue{issue: issue, fixes: []int{fix}})
+		}
+
+		var failedFixes []int
+		f.sw.TrackStage("all", func() {
+			failedFixes = f.fixFiles(issuesToFixPerFile)
+		})
+
+		// show issues only if can't fix them
+		for _, fix := range failedFixes {
+			outCh <- fixes[fix]
 		}
 		f.printStat()
 		close(outCh)
@@ -79,35 +105,184 @@ func (f Fixer) Process(issues <-chan result.Issue) <-chan result.Issue {
 	return outCh
 }
 
-func (f Fixer) fixIssuesInFile(filePath string, issues []result.Issue) error {
+// fixIssue is an issue to fix with indexes of fixes it applies: text edits of one fix are converted
+// into several issues and merged issues apply several fixes
+type fixIssue struct {
+	issue result.Issue
+	fixes []int
+}
+
+func (i fixIssue) hasFix(fixes map[int]bool) bool {
+	for _, fix := range i.fixes {
+		if fixes[fix] {
+			return true
+		}
+	}
+	return false
+}
+
+// fixFiles applies fixes to files and returns sorted indexes of fixes failed to apply.
+// A fix is applied all together or not at all: if one of its issues intersects with other issues,
+// the fix is skipped in all files and the remaining issues are prepared again.
+func (f *Fixer) fixFiles(issuesPerFile map[string][]fixIssue) []int {
+	// sort files to get stable order of fixes diffs
+	files := make([]string, 0, len(issuesPerFile))
+	for file := range issuesPerFile {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+
+	failedFixes := map[int]bool{}
+	skippedFixes := map[int]bool{}
+	fail := func(file string, err error) {
+		f.log.Errorf("Failed to fix issues in file %s: %s", file, err)
+		for _, i := range issuesPerFile[file] {
+			for _, fix := range i.fixes {
+				failedFixes[fix] = true
+				skippedFixes[fix] = true
+			}
+		}
+	}
+
 	// TODO: don't read the whole file into memory: read line by line;
 	// can't just use bufio.scanner: it has a line length limit
-	origFileData, err := f.fileCache.GetFileBytes(filePath)
-	if err != nil {
-		return errors.Wrapf(err, "failed to get file bytes for %s", filePath)
+	origFilesData := map[string][]byte{}
+	for _, file := range files {
+		data, err := f.fileCache.GetFileBytes(file)
+		if err != nil {
+			fail(file, errors.Wrapf(err, "failed to get file bytes for %s", file))
+			continue
+		}
+		origFilesData[file] = data
 	}
-	origFileLines := bytes.Split(origFileData, []byte("\n"))
 
-	tmpFileName := filepath.Join(filepath.Dir(filePath), fmt.Sprintf(".%s.golangci_fix", filepath.Base(filePath)))
-	tmpOutFile, err := os.Create(tmpFileName)
-	if err != nil {
-		return errors.Wrapf(err, "failed to make file %s", tmpFileName)
+	var issuesToApplyPerFile map[string][]result.Issue
+	for {
+		issuesToApplyPerFile = map[string][]result.Issue{}
+		var newSkippedFixes []int
+		for _, file := range files {
+			origFileData, ok := origFilesData[file]
+			if !ok {
+				continue
+			}
+
+			var issues []fixIssue
+			for _, i := range issuesPerFile[file] {
+				if !i.hasFix(skippedFixes) {
+					issues = append(issues, i)
+				}
+			}
+
+			issuesToApply, droppedFixes := f.prepareIssues(issues, bytes.Split(origFileData, []byte("\n")))
+			issuesToApplyPerFile[file] = issuesToApply
+			newSkippedFixes = append(newSkippedFixes, droppedFixes...)
+		}
+		if len(newSkippedFixes) == 0 {
+			break
+		}
+
+		for _, fix := range newSkippedFixes {
+			skippedFixes[fix] = true
+		}
 	}
 
-	// merge multiple issues per line into one issue
-	issuesPerLine := map[int][]result.Issue{}
-	for _, i := range issues {
-		issuesPerLine[i.Line()] = append(issuesPerLine[i.Line()], i)
+	for _, file := range files {
+		issues := issuesToApplyPerFile[file]
+		if len(issues) == 0 {
+			continue
+		}
+
+		if err := f.fixIssuesInFile(file, origFilesData[file], issues); err != nil {
+			fail(file, err)
+		}
+	}
+
+	ret := make([]int, 0, len(failedFixes))
+	for fix := range failedFixes {
+		ret = append(ret, fix)
+	}
+	sort.Ints(ret)
+	return ret
+}
+
+// textEditsToIssues converts byte text edits of the issue into line-based
+// replacements: one issue per edit. It allows to apply them together with
+// fixes from other linters and to skip intersecting fixes.
+func (f Fixer) textEditsToIssues(issue *result.Issue) ([]result.Issue, error) {
+	var ret []result.Issue
+	for _, edit := range issue.Replacement.TextEdits {
+		fileData, err := f.fileCache.GetFileBytes(edit.Filename)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get file bytes for %s", edit.Filename)
+		}
+
+		if edit.Offset < 0 || edit.EndOffset < edit.Offset || edit.EndOffset > len(fileData) {
+			return nil, fmt.Errorf("invalid text edit %#v for file %s with size %d", edit, edit.Filename, len(fileData))
+		}
+
+		startLine, startCol := offsetToLineCol(fileData, edit.Offset)
+		endLine, endCol := offsetToLineCol(fileData, edit.EndOffset)
+
+		editIssue := *issue
+		editIssue.Pos = token.Position{
+			Filename: edit.Filename,
+			Offset:   edit.Offset,
+			Line:     startLine,
+			Column:   startCol + 1,
+		}
+		editIssue.LineRange = nil
+
+		if startLine == endLine {
+			editIssue.Replacement = &result.Replacement{
+				Inline: &result.InlineFix{
+					StartCol:  startCol,
+					Length:    edit.EndOffset - edit.Offset,
+					NewString: edit.NewText,
+				},
+			}
+		} else {
+			fileLines := bytes.Split(fileData, []byte("\n"))
+			newText := string(fileLines[startLine-1][:startCol]) + edit.NewText + string(fileLines[endLine-1][endCol:])
+			editIssue.LineRange = &result.Range{
+				From: startLine,
+				To:   endLine,
+			}
+			editIssue.Replacement = &result.Replacement{
+				NewLines: strings.Split(newText, "\n"),
+			}
+		}
+
+		ret = append(ret, editIssue)
 	}
 
-	issues = issues[:0] // reuse the same memory
-	for line, lineIssues := range issuesPerLine {
-		if mergedIssue := f.mergeLineIssues(line, lineIssues, origFileLines); mergedIssue != nil {
-			issues = append(issues, *mergedIssue)
+	return ret, nil
+}
+
+// offsetToLineCol returns 1-based line and 0-based column of the byte offset
+func offsetToLineCol(data []byte, offset int) (line, col int) {
+	before := data[:offset]
+	line = bytes.Count(before, []byte("\n")) + 1
+	col = offset - (bytes.LastIndexByte(before, '\n') + 1)
+	return line, col
+}
+
+// fixIssuesInFile applies prepared issues to the file
+func (f *Fixer) fixIssuesInFile(filePath string, origFileData []byte, issues []result.Issue) error {
+	origFileLines := bytes.Split(origFileData, []byte("\n"))
+
+	if f.cfg.Issues.FixDiff {
+		var fixedFileData bytes.Buffer
+		if err := f.writeFixedFile(origFileLines, issues, &fixedFileData); err != nil {
+			return err
 		}
+		return f.addDiff(filePath, origFileData, fixedFileData.Bytes())
 	}
 
-	issues = f.findNotIntersectingIssues(issues)
+	tmpFileName := filepath.Join(filepath.Dir(filePath), fmt.Sprintf(".%s.golangci_fix", filepath.Base(filePath)))
+	tmpOutFile, err := os.Create(tmpFileName)
+	if err != nil {
+		return errors.Wrapf(err, "failed to make file %s", tmpFileName)
+	}
 
 	if err = f.writeFixedFile(origFileLines, issues, tmpOutFile); err != nil {
 		tmpOutFile.Close()
@@ -124,34 +299,108 @@ func (f Fixer) fixIssuesInFile(filePath string, issues []result.Issue) error {
 	return nil
 }
 
+// prepareIssues merges multiple issues per line into one issue and
+// removes intersecting issues. It returns issues to apply and fixes of removed issues.
+func (f Fixer) prepareIssues(issues []fixIssue, origFileLines [][]byte) ([]result.Issue, []int) {
+	issuesPerLine := map[int][]fixIssue{}
+	for _, i := range issues {
+		issuesPerLine[i.issue.Line()] = append(issuesPerLine[i.issue.Line()], i)
+	}
+
+	var droppedFixes []int
+	var mergedIssues []fixIssue
+	for line, lineFixIssues := range issuesPerLine {
+		lineIssues := make([]result.Issue, 0, len(lineFixIssues))
+		for _, i := range lineFixIssues {
+			lineIssues = append(lineIssues, i.issue)
+		}
+
+		mergedIssue, mergedAll := f.mergeLineIssues(line, lineIssues, origFileLines)
+		merged := fixIssue{}
+		for n, i := range lineFixIssues {
+			if mergedIssue != nil && (mergedAll || n == 0) {
+				merged.fixes = append(merged.fixes, i.fixes...)
+			} else {
+				droppedFixes = append(droppedFixes, i.fixes...)
+			}
+		}
+		if mergedIssue != nil {
+			merged.issue = *mergedIssue
+			mergedIssues = append(mergedIssues, merged)
+		}
+	}
+
+	issuesToApply, skippedIssues := f.findNotIntersectingIssues(mergedIssues)
+	for _, i := range skippedIssues {
+		droppedFixes = append(droppedFixes, i.fixes...)
+	}
+
+	ret := make([]result.Issue, 0, len(issuesToApply))
+	for _, i := range issuesToApply {
+		ret = append(ret, i.issue)
+	}
+	return ret, droppedFixes
+}
+
+func (f *Fixer) addDiff(filePath string, origFileData, fixedFileData []byte) error {
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitDiffLines(origFileData),
+		B:        splitDiffLines(fixedFileData),
+		FromFile: "a/" + filepath.ToSlash(filePath),
+		ToFile:   "b/" + filepath.ToSlash(filePath),
+		Context:  3,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to make diff for %s", filePath)
+	}
+
+	f.diff.WriteString(diff)
+	return nil
+}
+
+// splitDiffLines splits data into lines keeping line endings.
+// It differs from difflib.SplitLines: it doesn't add a fake empty last line
+// and marks the last line without a newline as the unified diff format does.
+func splitDiffLines(data []byte) []string {
+	lines := strings.SplitAfter(string(data), "\n")
+	if lines[len(lines)-1] == "" {
+		return lines[:len(lines)-1]
+	}
+
+	lines[len(lines)-1] += "\n\\ No newline at end of file\n"
+	return lines
+}
+
+// mergeLineIssues merges issues of the line into one issue. It returns false if only
+// the first issue is used: other issues are removed.
 //nolint:gocyclo
-func (f Fixer) mergeLineIssues(lineNum int, lineIssues []result.Issue, origFileLines [][]byte) *result.Issue {
+func (f Fixer) mergeLineIssues(lineNum int, lineIssues []result.Issue, origFileLines [][]byte) (*result.Issue, bool) {
 	origLine := origFileLines[lineNum-1] // lineNum is 1-based
 
 	if len(lineIssues) == 1 && lineIssues[0].Replacement.Inline == nil {
-		return &lineIssues[0]
+		return &lineIssues[0], true
 	}
 
 	// check issues first
 	for _, i := range lineIssues {
 		if i.LineRange != nil {
 			f.log.Infof("Line %d has multiple issues but at least one of them is ranged: %#v", lineNum, lineIssues)
-			return &lineIssues[0]
+			return &lineIssues[0], false
 		}
 
 		r := i.Replacement
 		if r.Inline == nil || len(r.NewLines) != 0 || r.NeedOnlyDelete {
 			f.log.Infof("Line %d has multiple issues but at least one of them isn't inline: %#v", lineNum, lineIssues)
-			return &lineIssues[0]
+			return &lineIssues[0], false
 		}
 
-		if r.Inline.StartCol < 0 || r.Inline.Length <= 0 || r.Inline.StartCol+r.Inline.Length > len(origLine) {
+		if r.Inline.StartCol < 0 || r.Inline.Length < 0 || r.Inline.StartCol+r.Inline.Length > len(origLine) {
 			f.log.Warnf("Line %d (%q) has invalid inline fix: %#v, %#v", lineNum, origLine, i, r.Inline)
-			return nil
+			return nil, false
 		}
 	}
 
-	return f.applyInlineFixes(lineIssues, origLine, lineNum)
+	return f.applyInlineFixes(lineIssues, origLine, lineNum), true
 }
 
 func (f Fixer) applyInlineFixes(lineIssues []result.Issue, origLine []byte, lineNum int) *result.Issue {
@@ -166,8 +415,14 @@ func (f Fixer) applyInlineFixes(lineIssues []result.Issue, origLine []byte, line
 	// example: origLine="it's becouse of them", StartCol=5, Length=7, NewString="because"
 
 	curOrigLinePos := 0
+	var prevFix *result.InlineFix
 	for _, i := range lineIssues {
 		fix := i.Replacement.Inline
+		if prevFix != nil && *fix == *prevFix {
+			continue // the same fix can be suggested by multiple issues
+		}
+		prevFix = fix
+
 		if fix.StartCol < curOrigLinePos {
 			f.log.Warnf("Line %d has multiple intersecting issues: %#v", lineNum, lineIssues)
 			return nil
@@ -190,29 +445,30 @@ func (f Fixer) applyInlineFixes(lineIssues []result.Issue, origLine []byte, line
 	return &mergedIssue
 }
 
-func (f Fixer) findNotIntersectingIssues(issues []result.Issue) []result.Issue {
+// findNotIntersectingIssues returns not intersecting issues sorted by lines and skipped intersecting issues
+func (f Fixer) findNotIntersectingIssues(issues []fixIssue) (ret, skipped []fixIssue) {
 	sort.SliceStable(issues, func(i, j int) bool {
-		a, b := issues[i], issues[j] //nolint:scopelint
+		a, b := issues[i].issue, issues[j].issue //nolint:scopelint
 		return a.Line() < b.Line()
 	})
 
-	var ret []result.Issue
 	var currentEnd int
-	for _, issue := range issues {
-		rng := issue.GetLineRange()
+	for _, i := range issues {
+		rng := i.issue.GetLineRange()
 		if rng.From <= currentEnd {
-			f.log.Infof("Skip issue %#v: intersects with end %d", issue, currentEnd)
-			continue // skip intersecting issue
+			f.log.Infof("Skip issue %#v: intersects with end %d", i.issue, currentEnd)
+			skipped = append(skipped, i) // skip intersecting issue
+			continue
 		}
-		f.log.Infof("Fix issue %#v with range %v", issue, issue.GetLineRange())
-		ret = append(ret, issue)
+		f.log.Infof("Fix issue %#v with range %v", i.issue, i.issue.GetLineRange())
+		ret = append(ret, i)
 		currentEnd = rng.To
 	}
 
-	return ret
+	return ret, skipped
 }
 
-func (f Fixer) writeFixedFile(origFileLines [][]byte, issues []result.Issue, tmpOutFile *os.File) error {
+func (f Fixer) writeFixedFile(origFileLines [][]byte, issues []result.Issue, out io.Writer) error {
 	// issues aren't intersecting
 
 	nextIssueIndex := 0
@@ -239,7 +495,7 @@ func (f Fixer) writeFixedFile(origFileLines [][]byte, issues []result.Issue, tmp
 		if i < len(origFileLines)-1 {
 			outLine += "\n"
 		}
-		if _, err := tmpOutFile.WriteString(outLine); err != nil {
+		if _, err := io.WriteString(out, outLine); err != nil {
 			return errors.Wrap(err, "failed to write output line")
 		}
 	}
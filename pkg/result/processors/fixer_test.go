@@ -0,0 +1,73 @@
+package processors
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/fsutils"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func TestFixerDiffNoNewlineAtEOF(t *testing.T) {
+	f := NewFixer(config.NewDefault(), logutils.NewStderrLog("fixer"), fsutils.NewFileCache())
+
+	require.NoError(t, f.addDiff("p.go", []byte("package p\n\nvar a = 1"), []byte("package p\n\nvar b = 1")))
+	assert.Equal(t, "--- a/p.go\n+++ b/p.go\n@@ -1,3 +1,3 @@\n package p\n \n"+
+		"-var a = 1\n\\ No newline at end of file\n+var b = 1\n\\ No newline at end of file\n", f.Diff())
+
+	f = NewFixer(config.NewDefault(), logutils.NewStderrLog("fixer"), fsutils.NewFileCache())
+	require.NoError(t, f.addDiff("p.go", []byte("var a = 1\nvar b = 2"), []byte("var a = 2\nvar b = 2")))
+	assert.Equal(t, `--- a/p.go
++++ b/p.go
+@@ -1,2 +1,2 @@
+-var a = 1
++var a = 2
+ var b = 2
+\ No newline at end of file
+`, f.Diff())
+}
+
+func TestFixerTextEditsOfFixAreAppliedTogether(t *testing.T) {
+	file, err := filepath.Abs("p.go")
+	require.NoError(t, err)
+	fileCache := fsutils.NewFileCache()
+	fileCache.SetOverlay(map[string][]byte{
+		file: []byte("package p\n\nvar a = 1\nvar b = 2\nvar c = 3\nvar d = 4\n"),
+	})
+
+	cfg := config.NewDefault()
+	cfg.Issues.NeedFix = true
+	cfg.Issues.FixDiff = true
+	f := NewFixer(cfg, logutils.NewStderrLog("fixer"), fileCache)
+
+	edits := func(edits ...result.TextEdit) *result.Replacement {
+		for i := range edits {
+			edits[i].Filename = file
+		}
+		return &result.Replacement{TextEdits: edits}
+	}
+	issues := make(chan result.Issue, 2)
+	// the multiline edit of lines 3-4 intersects with the edit of line 4 of the second fix:
+	// the first fix is applied, the edit of line 5 of the second fix isn't applied too
+	issues <- result.Issue{Text: "change numbers", Replacement: edits(
+		result.TextEdit{Offset: 19, EndOffset: 30, NewText: "10\nvar b = 20"},
+		result.TextEdit{Offset: 49, EndOffset: 50, NewText: "40"},
+	)}
+	issues <- result.Issue{Text: "rename vars", Replacement: edits(
+		result.TextEdit{Offset: 25, EndOffset: 26, NewText: "y"},
+		result.TextEdit{Offset: 35, EndOffset: 36, NewText: "z"},
+	)}
+	close(issues)
+
+	for issue := range f.Process(issues) {
+		t.Errorf("unexpected not fixed issue %#v", issue)
+	}
+	diffFile := "a/" + filepath.ToSlash(file)
+	assert.Equal(t, "--- "+diffFile+"\n+++ b"+diffFile[1:]+"\n@@ -1,6 +1,6 @@\n package p\n \n"+
+		"-var a = 1\n-var b = 2\n+var a = 10\n+var b = 20\n var c = 3\n-var d = 4\n+var d = 40\n", f.Diff())
+}
@@ -9,7 +9,11 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/fsutils"
+	"github.com/golangci/golangci-lint/pkg/golinters"
 	"github.com/golangci/golangci-lint/pkg/lint/astcache"
+	"github.com/golangci/golangci-lint/pkg/lint/linter"
 	"github.com/golangci/golangci-lint/pkg/lint/lintersdb"
 	"github.com/golangci/golangci-lint/pkg/logutils"
 	"github.com/golangci/golangci-lint/pkg/result"
@@ -17,10 +21,30 @@ import (
 
 var nolintDebugf = logutils.Debug("nolint")
 
+// nolintDirective is shared between an inline range and ranges expanded from it
+type nolintDirective struct {
+	text           string // original comment text
+	pos            token.Position
+	hasExplanation bool
+	unknownLinters bool
+
+	matchedIssueFromLinter map[string]bool
+}
+
+func (d *nolintDirective) newIssue(format string, args ...interface{}) result.Issue {
+	return result.Issue{
+		FromLinter: golinters.NolintlintName,
+		Text:       fmt.Sprintf(format, args...),
+		Pos:        d.pos,
+	}
+}
+
 type ignoredRange struct {
 	linters []string
 	result.Range
 	col int
+
+	directive *nolintDirective
 }
 
 func (i *ignoredRange) doesMatch(issue *result.Issue) bool {
@@ -48,25 +72,35 @@ type fileData struct {
 type filesCache map[string]*fileData
 
 type Nolint struct {
-	cache     filesCache
-	astCache  *astcache.Cache
-	dbManager *lintersdb.Manager
-	log       logutils.Log
+	cache          filesCache
+	astCache       *astcache.Cache
+	dbManager      *lintersdb.Manager
+	enabledLinters map[string]*linter.Config
+	settings       *config.NolintlintSettings
+	log            logutils.Log
 
 	unknownLintersSet map[string]bool
+	failedLinters     map[string]bool // their directives can't be checked: they reported no issues
 }
 
-func NewNolint(astCache *astcache.Cache, log logutils.Log, dbManager *lintersdb.Manager) *Nolint {
+func NewNolint(astCache *astcache.Cache, log logutils.Log, dbManager *lintersdb.Manager,
+	enabledLinters map[string]*linter.Config, settings *config.NolintlintSettings) *Nolint {
 	return &Nolint{
 		cache:             filesCache{},
 		astCache:          astCache,
 		dbManager:         dbManager,
+		enabledLinters:    enabledLinters,
+		settings:          settings,
 		log:               log,
 		unknownLintersSet: map[string]bool{},
+		failedLinters:     map[string]bool{},
 	}
 }
 
-var _ Processor = &Nolint{}
+var (
+	_ IssuesReporter        = &Nolint{}
+	_ FailedLintersRecorder = &Nolint{}
+)
 
 func (p Nolint) Name() string {
 	return "nolint"
@@ -76,30 +110,34 @@ func (p *Nolint) Process(issues []result.Issue) ([]result.Issue, error) {
 	return filterIssuesErr(issues, p.shouldPassIssue)
 }
 
-func (p *Nolint) getOrCreateFileData(i *result.Issue) (*fileData, error) {
-	fd := p.cache[i.FilePath()]
+func (p *Nolint) RecordFailedLinter(name string) {
+	p.failedLinters[name] = true
+}
+
+func (p *Nolint) getOrCreateFileData(filePath string) (*fileData, error) {
+	fd := p.cache[filePath]
 	if fd != nil {
 		return fd, nil
 	}
 
 	fd = &fileData{}
-	p.cache[i.FilePath()] = fd
+	p.cache[filePath] = fd
 
-	if i.FilePath() == "" {
+	if filePath == "" {
 		return nil, fmt.Errorf("no file path for issue")
 	}
 
-	file := p.astCache.Get(i.FilePath())
+	file := p.astCache.Get(filePath)
 	if file == nil {
 		return nil, fmt.Errorf("no file %s in ast cache %v",
-			i.FilePath(), p.astCache.ParsedFilenames())
+			filePath, p.astCache.ParsedFilenames())
 	}
 	if file.Err != nil {
-		return nil, errors.Wrapf(file.Err, "can't parse file %s", i.FilePath())
+		return nil, errors.Wrapf(file.Err, "can't parse file %s", filePath)
 	}
 
-	fd.ignoredRanges = p.buildIgnoredRangesForFile(file.F, file.Fset, i.FilePath())
-	nolintDebugf("file %s: built nolint ranges are %+v", i.FilePath(), fd.ignoredRanges)
+	fd.ignoredRanges = p.buildIgnoredRangesForFile(file.F, file.Fset, filePath)
+	nolintDebugf("file %s: built nolint ranges are %+v", filePath, fd.ignoredRanges)
 	return fd, nil
 }
 
@@ -126,15 +164,26 @@ func (p *Nolint) buildIgnoredRangesForFile(f *ast.File, fset *token.FileSet, fil
 }
 
 func (p *Nolint) shouldPassIssue(i *result.Issue) (bool, error) {
-	fd, err := p.getOrCreateFileData(i)
+	fd, err := p.getOrCreateFileData(i.FilePath())
 	if err != nil {
 		return false, err
 	}
 
 	for _, ir := range fd.ignoredRanges {
-		if ir.doesMatch(i) {
-			return false, nil
+		if !ir.doesMatch(i) {
+			continue
+		}
+
+		// issues about nolint directives can be hidden only by //nolint:nolintlint:
+		// otherwise //nolint would hide issues about itself
+		if i.FromLinter == golinters.NolintlintName && len(ir.linters) == 0 {
+			continue
+		}
+
+		if ir.directive != nil {
+			ir.directive.matchedIssueFromLinter[i.FromLinter] = true
 		}
+		return false, nil
 	}
 
 	return true, nil
@@ -182,7 +231,7 @@ func (p *Nolint) extractFileCommentsInlineRanges(fset *token.FileSet, comments .
 	var ret []ignoredRange
 	for _, g := range comments {
 		for _, c := range g.List {
-			ir := p.extractInlineRangeFromComment(c.Text, g, fset)
+			ir := p.extractInlineRangeFromComment(c, g, fset)
 			if ir != nil {
 				ret = append(ret, *ir)
 			}
@@ -192,12 +241,19 @@ func (p *Nolint) extractFileCommentsInlineRanges(fset *token.FileSet, comments .
 	return ret
 }
 
-func (p *Nolint) extractInlineRangeFromComment(text string, g ast.Node, fset *token.FileSet) *ignoredRange {
-	text = strings.TrimLeft(text, "/ ")
+func (p *Nolint) extractInlineRangeFromComment(c *ast.Comment, g ast.Node, fset *token.FileSet) *ignoredRange {
+	text := strings.TrimLeft(c.Text, "/ ")
 	if !strings.HasPrefix(text, "nolint") {
 		return nil
 	}
 
+	directive := &nolintDirective{
+		text:                   strings.TrimSpace(c.Text),
+		pos:                    fset.Position(c.Pos()),
+		hasExplanation:         strings.Contains(text, "//"),
+		matchedIssueFromLinter: map[string]bool{},
+	}
+
 	buildRange := func(linters []string) *ignoredRange {
 		pos := fset.Position(g.Pos())
 		return &ignoredRange{
@@ -205,8 +261,9 @@ func (p *Nolint) extractInlineRangeFromComment(text string, g ast.Node, fset *to
 				From: pos.Line,
 				To:   fset.Position(g.End()).Line,
 			},
-			col:     pos.Column,
-			linters: linters,
+			col:       pos.Column,
+			linters:   linters,
+			directive: directive,
 		}
 	}
 
@@ -240,6 +297,7 @@ func (p *Nolint) extractInlineRangeFromComment(text string, g ast.Node, fset *to
 	}
 
 	if gotUnknownLinters {
+		directive.unknownLinters = true
 		return buildRange(nil) // ignore all linters to not annoy user
 	}
 
@@ -247,6 +305,92 @@ func (p *Nolint) extractInlineRangeFromComment(text string, g ast.Node, fset *to
 	return buildRange(linters)
 }
 
+func (p *Nolint) ReportedIssues() []result.Issue {
+	if p.enabledLinters[golinters.NolintlintName] == nil {
+		return nil
+	}
+
+	// directives in files without issues are checked too
+	for _, f := range p.astCache.GetAllValidFiles() {
+		filePath, err := fsutils.ShortestRelPath(f.Name, "")
+		if err != nil {
+			filePath = f.Name
+		}
+
+		if _, err = p.getOrCreateFileData(filePath); err != nil {
+			p.log.Warnf("Can't check nolint directives in file %s: %s", filePath, err)
+		}
+	}
+
+	var filePaths []string
+	for filePath := range p.cache {
+		filePaths = append(filePaths, filePath)
+	}
+	sort.Strings(filePaths)
+
+	var ret []result.Issue
+	for _, filePath := range filePaths {
+		seenDirectives := map[*nolintDirective]bool{}
+		for _, ir := range p.cache[filePath].ignoredRanges {
+			if ir.directive == nil || seenDirectives[ir.directive] {
+				continue
+			}
+			seenDirectives[ir.directive] = true
+
+			ret = append(ret, p.checkDirective(ir.directive, ir.linters)...)
+		}
+	}
+
+	return ret
+}
+
+func (p *Nolint) checkDirective(d *nolintDirective, linters []string) []result.Issue {
+	// unused directive should be just removed: don't report anything else about it
+	if unusedIssues := p.checkUnusedDirective(d, linters); len(unusedIssues) != 0 {
+		return unusedIssues
+	}
+
+	var ret []result.Issue
+	if p.settings.RequireSpecific && len(linters) == 0 && !d.unknownLinters {
+		ret = append(ret, d.newIssue("directive `%s` should mention specific linter such as `//nolint:my-linter`", d.text))
+	}
+	if p.settings.RequireExplanation && !d.hasExplanation {
+		ret = append(ret, d.newIssue("directive `%s` should provide explanation such as `//nolint:my-linter // this is why`", d.text))
+	}
+
+	return ret
+}
+
+func (p *Nolint) checkUnusedDirective(d *nolintDirective, linters []string) []result.Issue {
+	if p.settings.AllowUnused || d.unknownLinters {
+		return nil
+	}
+
+	if len(linters) == 0 {
+		// the directive can be used by a failed linter
+		if len(d.matchedIssueFromLinter) == 0 && len(p.failedLinters) == 0 {
+			return []result.Issue{d.newIssue("directive `%s` is unused", d.text)}
+		}
+		return nil
+	}
+
+	var ret []result.Issue
+
+	for _, linterName := range linters {
+		if linterName == golinters.NolintlintName || d.matchedIssueFromLinter[linterName] || p.failedLinters[linterName] {
+			continue
+		}
+
+		if p.enabledLinters[linterName] == nil {
+			ret = append(ret, d.newIssue("directive `%s` is for not enabled linter %s", d.text, linterName))
+		} else {
+			ret = append(ret, d.newIssue("directive `%s` is unused for linter %s", d.text, linterName))
+		}
+	}
+
+	return ret
+}
+
 func (p Nolint) Finish() {
 	if len(p.unknownLintersSet) == 0 {
 		return
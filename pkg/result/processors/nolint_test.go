@@ -6,6 +6,9 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/golinters"
+	"github.com/golangci/golangci-lint/pkg/lint/linter"
 	"github.com/golangci/golangci-lint/pkg/lint/lintersdb"
 
 	"github.com/golang/mock/gomock"
@@ -40,7 +43,7 @@ func newTestNolintProcessor(log logutils.Log) *Nolint {
 		filepath.Join("testdata", "nolint_bad_names.go"),
 		filepath.Join("testdata", "nolint_whole_file.go"),
 	)
-	return NewNolint(cache, log, lintersdb.NewManager(nil))
+	return NewNolint(cache, log, lintersdb.NewManager(nil), nil, nil)
 }
 
 func getOkLogger(ctrl *gomock.Controller) *mock_logutils.MockLog {
@@ -235,6 +238,109 @@ func TestIgnoredRangeMatches(t *testing.T) {
 	}
 }
 
+func TestNolintUnused(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	log := getOkLogger(ctrl)
+
+	fileName := filepath.Join("testdata", "nolint_unused.go")
+	cache := astcache.LoadFromFilenames(log, fileName)
+	dbManager := lintersdb.NewManager(nil)
+	enabledLinters := map[string]*linter.Config{}
+	for _, name := range []string{"varcheck", "deadcode", golinters.NolintlintName} {
+		enabledLinters[name] = dbManager.GetLinterConfig(name)
+	}
+	p := NewNolint(cache, log, dbManager, enabledLinters, &config.NolintlintSettings{
+		RequireSpecific:    true,
+		RequireExplanation: true,
+	})
+	defer p.Finish()
+
+	processAssertEmpty(t, p, result.Issue{
+		Pos: token.Position{
+			Filename: fileName,
+			Line:     5,
+		},
+		FromLinter: "deadcode",
+	})
+	for _, line := range []int{11, 13} {
+		processAssertEmpty(t, p, result.Issue{
+			Pos: token.Position{
+				Filename: fileName,
+				Line:     line,
+			},
+			FromLinter: "varcheck",
+		})
+	}
+	// //nolint doesn't hide issues about itself
+	processAssertSame(t, p, result.Issue{
+		Pos: token.Position{
+			Filename: fileName,
+			Line:     7,
+		},
+		FromLinter: golinters.NolintlintName,
+	})
+
+	type issueCase struct {
+		Line int
+		Text string
+	}
+	var reportedCases []issueCase
+	for _, i := range p.ReportedIssues() {
+		assert.Equal(t, golinters.NolintlintName, i.FromLinter)
+		reportedCases = append(reportedCases, issueCase{Line: i.Line(), Text: i.Text})
+	}
+
+	expectedCases := []issueCase{
+		{Line: 3, Text: "directive `// nolint:varcheck // explanation` is unused for linter varcheck"},
+		{Line: 5, Text: "directive `// nolint:varcheck,deadcode // explanation` is unused for linter varcheck"},
+		{Line: 7, Text: "directive `//nolint` is unused"},
+		{Line: 9, Text: "directive `//nolint:gocyclo // explanation` is for not enabled linter gocyclo"},
+		{Line: 11, Text: "directive `//nolint` should mention specific linter such as `//nolint:my-linter`"},
+		{Line: 11, Text: "directive `//nolint` should provide explanation such as `//nolint:my-linter // this is why`"},
+		{Line: 13, Text: "directive `//nolint:varcheck` should provide explanation such as `//nolint:my-linter // this is why`"},
+	}
+	assert.Equal(t, expectedCases, reportedCases)
+}
+
+func TestNolintUnusedFailedLinter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	log := getOkLogger(ctrl)
+
+	fileName := filepath.Join("testdata", "nolint_unused.go")
+	cache := astcache.LoadFromFilenames(log, fileName)
+	dbManager := lintersdb.NewManager(nil)
+	enabledLinters := map[string]*linter.Config{}
+	for _, name := range []string{"varcheck", "deadcode", golinters.NolintlintName} {
+		enabledLinters[name] = dbManager.GetLinterConfig(name)
+	}
+	p := NewNolint(cache, log, dbManager, enabledLinters, &config.NolintlintSettings{})
+	defer p.Finish()
+
+	// e.g. varcheck timed out: its directives and directives for all linters aren't unused
+	p.RecordFailedLinter("varcheck")
+
+	var texts []string
+	for _, i := range p.ReportedIssues() {
+		texts = append(texts, i.Text)
+	}
+	assert.Equal(t, []string{
+		"directive `// nolint:varcheck,deadcode // explanation` is unused for linter deadcode",
+		"directive `//nolint:gocyclo // explanation` is for not enabled linter gocyclo",
+	}, texts)
+}
+
+func TestNolintUnusedNotEnabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	p := newTestNolintProcessor(getOkLogger(ctrl))
+	defer p.Finish()
+
+	assert.Empty(t, p.ReportedIssues())
+}
+
 func TestNolintWholeFile(t *testing.T) {
 	fileName := filepath.Join("testdata", "nolint_whole_file.go")
 
@@ -30,19 +30,35 @@ func (p PathPrettifier) Name() string {
 
 func (p PathPrettifier) Process(issues []result.Issue) ([]result.Issue, error) {
 	return transformIssues(issues, func(i *result.Issue) *result.Issue {
-		if !filepath.IsAbs(i.FilePath()) {
-			return i
-		}
+		newI := i
+		newI.Pos.Filename = prettifyPath(i.FilePath())
 
-		rel, err := fsutils.ShortestRelPath(i.FilePath(), "")
-		if err != nil {
-			return i
+		if i.Replacement != nil && len(i.Replacement.TextEdits) != 0 {
+			// text edits must have the same paths as issues to be applied by the fixer
+			r := *i.Replacement
+			r.TextEdits = make([]result.TextEdit, 0, len(i.Replacement.TextEdits))
+			for _, edit := range i.Replacement.TextEdits {
+				edit.Filename = prettifyPath(edit.Filename)
+				r.TextEdits = append(r.TextEdits, edit)
+			}
+			newI.Replacement = &r
 		}
 
-		newI := i
-		newI.Pos.Filename = rel
 		return newI
 	}), nil
 }
 
+func prettifyPath(path string) string {
+	if !filepath.IsAbs(path) {
+		return path
+	}
+
+	rel, err := fsutils.ShortestRelPath(path, "")
+	if err != nil {
+		return path
+	}
+
+	return rel
+}
+
 func (p PathPrettifier) Finish() {}
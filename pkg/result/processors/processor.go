@@ -7,3 +7,24 @@ type Processor interface {
 	Name() string
 	Finish()
 }
+
+// IssuesReporter is a processor which reports own issues after
+// processing issues of all linters.
+type IssuesReporter interface {
+	Processor
+	ReportedIssues() []result.Issue
+}
+
+// FailedLintersRecorder is a processor which must know linters failed in the run:
+// they reported no issues, e.g. because of an error or a timeout.
+type FailedLintersRecorder interface {
+	Processor
+	RecordFailedLinter(name string)
+}
+
+// LintedRecorder is a processor which must know what was linted in the run:
+// not all files and not all linters are linted in every run.
+type LintedRecorder interface {
+	Processor
+	RecordLinted(linters, files []string)
+}
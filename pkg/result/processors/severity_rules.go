@@ -0,0 +1,66 @@
+package processors
+
+import (
+	"github.com/golangci/golangci-lint/pkg/fsutils"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+type severityRule struct {
+	baseRule
+	severity string
+}
+
+type SeverityRule struct {
+	BaseRule
+	Severity string
+}
+
+type SeverityRules struct {
+	defaultSeverity string
+	rules           []severityRule
+	lineCache       *fsutils.LineCache
+	log             logutils.Log
+}
+
+func NewSeverityRules(defaultSeverity string, rules []SeverityRule, lineCache *fsutils.LineCache, log logutils.Log) *SeverityRules {
+	r := &SeverityRules{
+		defaultSeverity: defaultSeverity,
+		lineCache:       lineCache,
+		log:             log,
+	}
+
+	for _, rule := range rules {
+		rule := rule
+		r.rules = append(r.rules, severityRule{
+			baseRule: newBaseRule(&rule.BaseRule),
+			severity: rule.Severity,
+		})
+	}
+
+	return r
+}
+
+func (p SeverityRules) Process(issues []result.Issue) ([]result.Issue, error) {
+	if len(p.rules) == 0 && p.defaultSeverity == "" {
+		return issues, nil
+	}
+	return transformIssues(issues, func(i *result.Issue) *result.Issue {
+		for _, rule := range p.rules {
+			rule := rule
+			if rule.match(i, p.lineCache, p.log) {
+				i.Severity = rule.severity
+				return i
+			}
+		}
+		if i.Severity == "" {
+			i.Severity = p.defaultSeverity
+		}
+		return i
+	}), nil
+}
+
+func (SeverityRules) Name() string { return "severity-rules" }
+func (SeverityRules) Finish()      {}
+
+var _ Processor = SeverityRules{}
@@ -0,0 +1,98 @@
+package processors
+
+import (
+	"go/token"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/golangci/golangci-lint/pkg/fsutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func TestSeverityRulesMultiple(t *testing.T) {
+	lineCache := fsutils.NewLineCache(fsutils.NewFileCache())
+	p := NewSeverityRules("error", []SeverityRule{
+		{
+			Severity: "info",
+			BaseRule: BaseRule{
+				Text:    "^ssl$",
+				Linters: []string{"gosec"},
+			},
+		},
+		{
+			Severity: "info",
+			BaseRule: BaseRule{
+				Linters: []string{"linter"},
+				Path:    `_test\.go`,
+			},
+		},
+		{
+			Severity: "info",
+			BaseRule: BaseRule{
+				Source:  "^//go:generate ",
+				Linters: []string{"lll"},
+			},
+		},
+		{
+			Severity: "warning",
+			BaseRule: BaseRule{
+				Linters: []string{"golint"},
+			},
+		},
+	}, lineCache, nil)
+	type issueCase struct {
+		Path     string
+		Line     int
+		Text     string
+		Linter   string
+		Severity string
+	}
+	var newIssueCase = func(c issueCase) result.Issue {
+		return result.Issue{
+			Text:       c.Text,
+			FromLinter: c.Linter,
+			Pos: token.Position{
+				Filename: c.Path,
+				Line:     c.Line,
+			},
+		}
+	}
+	cases := []issueCase{
+		{Path: "ssl.go", Text: "ssl", Linter: "gosec"},
+		{Path: "e.go", Text: "some", Linter: "linter"},
+		{Path: "e_test.go", Text: "normal", Linter: "testlinter"},
+		{Path: "e_test.go", Text: "another", Linter: "linter"},
+		{Path: "e.go", Text: "style", Linter: "golint"},
+		{Path: filepath.Join("testdata", "exclude_rules.go"), Line: 3, Linter: "lll"},
+	}
+	var issues []result.Issue
+	for _, c := range cases {
+		issues = append(issues, newIssueCase(c))
+	}
+	processedIssues := process(t, p, issues...)
+	var resultingCases []issueCase
+	for _, i := range processedIssues {
+		resultingCases = append(resultingCases, issueCase{
+			Path:     i.FilePath(),
+			Linter:   i.FromLinter,
+			Text:     i.Text,
+			Line:     i.Line(),
+			Severity: i.Severity,
+		})
+	}
+	expectedCases := []issueCase{
+		{Path: "ssl.go", Text: "ssl", Linter: "gosec", Severity: "info"},
+		{Path: "e.go", Text: "some", Linter: "linter", Severity: "error"},
+		{Path: "e_test.go", Text: "normal", Linter: "testlinter", Severity: "error"},
+		{Path: "e_test.go", Text: "another", Linter: "linter", Severity: "info"},
+		{Path: "e.go", Text: "style", Linter: "golint", Severity: "warning"},
+		{Path: filepath.Join("testdata", "exclude_rules.go"), Line: 3, Linter: "lll", Severity: "info"},
+	}
+	assert.Equal(t, expectedCases, resultingCases)
+}
+
+func TestSeverityRulesEmpty(t *testing.T) {
+	processAssertSame(t, NewSeverityRules("", nil, nil, nil), newTextIssue("test"))
+}
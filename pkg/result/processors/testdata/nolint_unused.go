@@ -0,0 +1,13 @@
+package testdata
+
+var nolintUnused int // nolint:varcheck // explanation
+
+var nolintUsed int // nolint:varcheck,deadcode // explanation
+
+var nolintAllUnused int //nolint
+
+var nolintNotEnabled int //nolint:gocyclo // explanation
+
+var nolintNotSpecific int //nolint
+
+var nolintNotExplained int //nolint:varcheck
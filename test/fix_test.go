@@ -1,6 +1,7 @@
 package test
 
 import (
+	"encoding/json"
 	"io/ioutil"
 	"os"
 	"os/exec"
@@ -10,6 +11,7 @@ import (
 	assert "github.com/stretchr/testify/require"
 	yaml "gopkg.in/yaml.v2"
 
+	"github.com/golangci/golangci-lint/pkg/exitcodes"
 	"github.com/golangci/golangci-lint/test/testshared"
 )
 
@@ -59,3 +61,54 @@ func TestFix(t *testing.T) {
 		})
 	}
 }
+
+func TestFixDiff(t *testing.T) {
+	input := filepath.Join(testdataDir, "fix", "in", "misspell.go")
+	origInput, err := ioutil.ReadFile(input)
+	assert.NoError(t, err)
+
+	testshared.NewLintRunner(t).Run("--no-config", "--disable-all", "-Emisspell", "--fix", "--fix-diff", input).
+		ExpectExitCode(exitcodes.IssuesFound).
+		ExpectOutputContains("--- a/testdata/fix/in/misspell.go\n+++ b/testdata/fix/in/misspell.go\n").
+		ExpectOutputContains("-// langauge lala\n-// lala langauge\n").
+		ExpectOutputContains("+// language lala\n+// lala language\n")
+
+	// the file must not be changed
+	output, err := ioutil.ReadFile(input)
+	assert.NoError(t, err)
+	assert.Equal(t, string(origInput), string(output))
+}
+
+func TestFixDiffOverlay(t *testing.T) {
+	dir, err := ioutil.TempDir("", "golangci_lint_test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// the file on disk is gofmt-ed: replacements must be made for the overlay contents
+	replacementPath := filepath.Join(dir, "a.go")
+	const src = "package files\n\nfunc a()   {\n\t\tprintln()\n}\n"
+	assert.NoError(t, ioutil.WriteFile(replacementPath, []byte(src), os.ModePerm))
+
+	overlay, err := json.Marshal(map[string]interface{}{
+		"Replace": map[string]string{getTestDataDir("files", "a.go"): replacementPath},
+	})
+	assert.NoError(t, err)
+	overlayPath := filepath.Join(dir, "overlay.json")
+	assert.NoError(t, ioutil.WriteFile(overlayPath, overlay, os.ModePerm))
+
+	testshared.NewLintRunner(t).Run("--no-config", "--disable-all", "-Egofmt", "--fix", "--fix-diff",
+		"--overlay", overlayPath, getTestDataDir("files")).
+		ExpectExitCode(exitcodes.IssuesFound).
+		ExpectOutputContains("--- a/testdata/files/a.go\n+++ b/testdata/files/a.go\n").
+		ExpectOutputContains("-func a()   {\n-\t\tprintln()\n+func a() {\n+\tprintln()\n")
+
+	// keys of overlays have evaluated symlinks: the overlay is used for files below symlinked directories
+	linkDir := getTestDataDir("files_symlink")
+	assert.NoError(t, os.Symlink("files", linkDir))
+	defer os.Remove(linkDir)
+
+	testshared.NewLintRunner(t).Run("--no-config", "--disable-all", "-Egoimports", "--fix", "--fix-diff",
+		"--skip-dirs-use-default=false", "--overlay", overlayPath, linkDir).
+		ExpectExitCode(exitcodes.IssuesFound).
+		ExpectOutputContains("-func a()   {\n-\t\tprintln()\n+func a() {\n+\tprintln()\n")
+}
@@ -1,15 +1,21 @@
 package test
 
 import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/golangci/golangci-lint/test/testshared"
 
 	"github.com/golangci/golangci-lint/pkg/exitcodes"
+	"github.com/golangci/golangci-lint/pkg/printers"
 
 	_ "github.com/valyala/quicktemplate"
 )
@@ -49,6 +55,139 @@ func TestDeadline(t *testing.T) {
 		ExpectOutputContains(`Deadline exceeded: try increase it by passing --deadline option`)
 }
 
+func TestLinterTimeout(t *testing.T) {
+	cfg := `
+		linters-settings:
+			external:
+				sleep:
+					command: [sleep, "10"]
+					pattern: ['^(?P<file>.+):(?P<line>\d+): (?P<message>.+)$']
+			timeouts:
+				sleep: 100ms
+	`
+
+	testshared.NewLintRunner(t).RunWithYamlConfig(cfg, "--disable-all", "-Esleep", "-Egodox",
+		getTestDataDir("godox.go")).
+		ExpectHasIssue(`Line contains TODO/BUG/FIXME: "TODO implement me`).
+		ExpectOutputContains("Can't run linter sleep: timed out after 100ms")
+
+	cfg = `
+		linters-settings:
+			timeouts:
+				unparam: 1m
+	`
+	testshared.NewLintRunner(t).RunWithYamlConfig(cfg, "--disable-all", "-Eunparam", getTestDataDir("godox.go")).
+		ExpectExitCode(exitcodes.Failure).
+		ExpectOutputContains("linters-settings.timeouts: linter unparam can't be stopped on a timeout")
+}
+
+func TestMultipleOutFormats(t *testing.T) {
+	dir, err := ioutil.TempDir("", "golangci_lint_test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	jsonPath := filepath.Join(dir, "out.json")
+	checkstylePath := filepath.Join(dir, "report.xml")
+	testshared.NewLintRunner(t).Run("--no-config", "--disable-all", "-Egodox",
+		"--out-format=line-number,json:"+jsonPath+",checkstyle:"+checkstylePath, getTestDataDir("godox.go")).
+		ExpectHasIssue(`Line contains TODO/BUG/FIXME: "TODO implement me`)
+
+	jsonOut, err := ioutil.ReadFile(jsonPath)
+	assert.NoError(t, err)
+	var res printers.JSONResult
+	assert.NoError(t, json.Unmarshal(jsonOut, &res))
+	assert.Len(t, res.Issues, 7)
+
+	checkstyleOut, err := ioutil.ReadFile(checkstylePath)
+	assert.NoError(t, err)
+	assert.Contains(t, string(checkstyleOut), `source="godox"`)
+
+	testshared.NewLintRunner(t).Run("--no-config", "--disable-all", "-Egodox",
+		"--out-format=line-number,json", getTestDataDir("godox.go")).
+		ExpectExitCode(exitcodes.Failure).
+		ExpectOutputContains("output formats line-number and json are both printed to stdout")
+}
+
+func TestFilesOption(t *testing.T) {
+	testshared.NewLintRunner(t).Run("--no-config", "--disable-all", "-Egodox",
+		"--files", getTestDataDir("files", "a.go")).
+		ExpectHasIssue("TODO implement me in a.go").
+		ExpectOutputNotContains("b.go")
+}
+
+func TestStdinFilename(t *testing.T) {
+	const src = "package files\n\nfunc a() {} // TODO implement me from stdin\n"
+
+	testshared.NewLintRunner(t).RunWithStdin(src, "--no-config", "--disable-all", "-Egodox",
+		"--stdin-filename", getTestDataDir("files", "a.go")).
+		ExpectHasIssue("TODO implement me from stdin").
+		ExpectOutputNotContains("in a.go").
+		ExpectOutputNotContains("b.go")
+}
+
+func TestOverlay(t *testing.T) {
+	dir, err := ioutil.TempDir("", "golangci_lint_test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	replacementPath := filepath.Join(dir, "a.go")
+	const src = "package files\n\nfunc a() {} // TODO implement me from overlay\n"
+	assert.NoError(t, ioutil.WriteFile(replacementPath, []byte(src), os.ModePerm))
+
+	overlay, err := json.Marshal(map[string]interface{}{
+		"Replace": map[string]string{getTestDataDir("files", "a.go"): replacementPath},
+	})
+	assert.NoError(t, err)
+	overlayPath := filepath.Join(dir, "overlay.json")
+	assert.NoError(t, ioutil.WriteFile(overlayPath, overlay, os.ModePerm))
+
+	testshared.NewLintRunner(t).Run("--no-config", "--disable-all", "-Egodox",
+		"--overlay", overlayPath, getTestDataDir("files")).
+		ExpectHasIssue("TODO implement me from overlay").
+		ExpectOutputContains("TODO implement me in b.go").
+		ExpectOutputNotContains("in a.go")
+}
+
+func TestDaemon(t *testing.T) {
+	dir, err := ioutil.TempDir("", "golangci_lint_test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	r := testshared.NewLintRunner(t)
+	r.Install()
+
+	socketPath := filepath.Join(dir, "daemon.sock")
+	serveCmd := exec.Command("../golangci-lint", "serve", "--no-config", "--disable-all", "-Egodox",
+		"--daemon-socket", socketPath)
+	assert.NoError(t, serveCmd.Start())
+	defer func() {
+		assert.NoError(t, serveCmd.Process.Signal(os.Interrupt))
+		assert.NoError(t, serveCmd.Wait())
+	}()
+
+	for i := 0; i < 100; i++ {
+		if _, err = os.Stat(socketPath); err == nil {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	assert.NoError(t, err, "daemon didn't create socket")
+
+	r.Run("--no-config", "--daemon", "--daemon-socket", socketPath, getTestDataDir("files")).
+		ExpectHasIssue("TODO implement me in a.go").
+		ExpectOutputContains("TODO implement me in b.go")
+
+	const src = "package files\n\nfunc a() {} // TODO implement me from stdin\n"
+	r.RunWithStdin(src, "--no-config", "--daemon", "--daemon-socket", socketPath,
+		"--stdin-filename", getTestDataDir("files", "a.go")).
+		ExpectHasIssue("TODO implement me from stdin").
+		ExpectOutputNotContains("b.go")
+
+	r.Run("--no-config", "--daemon", "--daemon-socket", socketPath, "-Egovet", getTestDataDir("files")).
+		ExpectHasIssue("TODO implement me in a.go").
+		ExpectOutputContains("Options --enable are ignored by the daemon")
+}
+
 func TestTestsAreLintedByDefault(t *testing.T) {
 	testshared.NewLintRunner(t).Run(getTestDataDir("withtests")).
 		ExpectHasIssue("`if` block ends with a `return`")
@@ -122,6 +261,92 @@ func TestUnusedCheckExported(t *testing.T) {
 	testshared.NewLintRunner(t).Run("-c", "testdata_etc/unused_exported/golangci.yml", "testdata_etc/unused_exported/...").ExpectNoIssues()
 }
 
+func TestBuildMatrix(t *testing.T) {
+	dir, err := ioutil.TempDir("", "golangci_lint_test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	jsonPath := filepath.Join(dir, "out.json")
+	testshared.NewLintRunner(t).Run("-c", "testdata_etc/build_matrix/golangci.yml",
+		"--out-format=line-number,json:"+jsonPath, "testdata_etc/build_matrix/...").
+		ExpectHasIssue("TODO lint on linux").
+		ExpectHasIssue("TODO lint on windows").
+		ExpectHasIssue("TODO lint with integration tag")
+
+	jsonOut, err := ioutil.ReadFile(jsonPath)
+	assert.NoError(t, err)
+	var res printers.JSONResult
+	assert.NoError(t, json.Unmarshal(jsonOut, &res))
+
+	builds := map[string][]string{}
+	for _, i := range res.Issues {
+		builds[filepath.Base(i.FilePath())] = i.BuildCombinations
+	}
+	assert.Equal(t, map[string][]string{
+		"main.go":         {"goos=linux", "goos=windows", "goos=linux,tags=integration"}, // reported once for all builds
+		"main_linux.go":   {"goos=linux", "goos=linux,tags=integration"},
+		"main_windows.go": {"goos=windows"},
+		"integration.go":  {"goos=linux,tags=integration"},
+	}, builds)
+}
+
+func TestModules(t *testing.T) {
+	const cfg = "testdata_etc/modules/golangci.yml"
+	testshared.NewLintRunner(t).Run("-c", cfg, "--discover-modules", "testdata_etc/modules/...").
+		ExpectHasIssue("TODO lint root module").
+		ExpectHasIssue("TODO lint listed module").
+		ExpectHasIssue("testdata_etc/modules/lib/sub/sub.go:3: Line contains FIXME: \"FIXME lint nested module").
+		ExpectOutputNotContains("not reported by lib config")
+
+	testshared.NewLintRunner(t).Run("-c", cfg, "--modules", "testdata_etc/modules/tools", "testdata_etc/modules/...").
+		ExpectHasIssue("TODO lint listed module").
+		ExpectOutputNotContains("lint root module").
+		ExpectOutputNotContains("nested module")
+}
+
+func TestNestedConfigs(t *testing.T) {
+	const cfg = "testdata_etc/nested_configs/golangci.yml"
+	r := testshared.NewLintRunner(t)
+	r.Run("-c", cfg, "testdata_etc/nested_configs/...").
+		ExpectHasIssue("TODO lint root package").
+		ExpectHasIssue("FIXME lint sub package").
+		ExpectHasIssue("FIXME lint deep package").
+		ExpectHasIssue(`declaration of "shadowingBySubConfig" shadows declaration`).
+		ExpectOutputNotContains("not reported by sub config").
+		ExpectOutputNotContains("notShadowingByRootConfig").
+		ExpectOutputNotContains("excluded by deep config")
+
+	out, err := exec.Command("../golangci-lint", "config", "path", "-c", cfg,
+		"testdata_etc/nested_configs/sub/deep").CombinedOutput()
+	assert.NoError(t, err, string(out))
+	assert.Equal(t, "testdata_etc/nested_configs/golangci.yml\n"+
+		"testdata_etc/nested_configs/sub/.golangci.yml (overrides issues and linters-settings)\n"+
+		"testdata_etc/nested_configs/sub/deep/.golangci.yml (overrides issues and linters-settings)\n", string(out))
+}
+
+func TestConfigInit(t *testing.T) {
+	dir, err := ioutil.TempDir("", "golangci_lint_test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	r := testshared.NewLintRunner(t)
+	r.Install()
+
+	cfg := filepath.Join(dir, "golangci.yml")
+	out, err := exec.Command("../golangci-lint", "config", "init", "--no-config", "--disable-all", "-Egodox",
+		"--min-excluded-issues", "2", "--output-file", cfg, "testdata_etc/config_init/...").CombinedOutput()
+	assert.NoError(t, err, string(out))
+	assert.Contains(t, string(out), "4 issues found, 3 of them are excluded by 1 rules")
+
+	cfgContent, err := ioutil.ReadFile(cfg)
+	assert.NoError(t, err)
+	assert.Contains(t, string(cfgContent), "path: '^testdata_etc/config_init/noisy/[^/]+$'")
+
+	r.Run("-c", cfg, "testdata_etc/config_init/...").
+		ExpectHasIssue("TODO: reported quiet issue").
+		ExpectOutputNotContains("excluded noisy issue")
+}
+
 func TestConfigFileIsDetected(t *testing.T) {
 	checkGotConfig := func(r *testshared.RunResult) {
 		r.ExpectExitCode(exitcodes.Success).
@@ -199,6 +424,30 @@ func TestDisallowedOptionsInConfig(t *testing.T) {
 			`,
 			option: "-v",
 		},
+		{
+			cfg: `
+				run:
+					files: true
+			`,
+		},
+		{
+			cfg: `
+				run:
+					stdin-filename: main.go
+			`,
+		},
+		{
+			cfg: `
+				run:
+					overlay: overlay.json
+			`,
+		},
+		{
+			cfg: `
+				run:
+					daemon: true
+			`,
+		},
 	}
 
 	r := testshared.NewLintRunner(t)
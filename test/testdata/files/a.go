@@ -0,0 +1,3 @@
+package files
+
+func a() {} // TODO implement me in a.go
@@ -0,0 +1,3 @@
+package files
+
+func b() {} // TODO implement me in b.go
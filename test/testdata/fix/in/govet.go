@@ -0,0 +1,10 @@
+//args: -Egovet
+package p
+
+func assign() int {
+	x := 1
+	x = x
+	y := 2
+	y, x = y, x
+	return x + y
+}
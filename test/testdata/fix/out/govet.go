@@ -0,0 +1,10 @@
+//args: -Egovet
+package p
+
+func assign() int {
+	x := 1
+	
+	y := 2
+	
+	return x + y
+}
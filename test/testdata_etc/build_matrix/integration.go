@@ -0,0 +1,5 @@
+// +build integration
+
+package main
+
+func integration() {} // TODO lint with integration tag
@@ -0,0 +1,3 @@
+package main
+
+func linux() {} // TODO lint on linux
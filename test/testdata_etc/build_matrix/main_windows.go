@@ -0,0 +1,3 @@
+package main
+
+func windows() {} // TODO lint on windows
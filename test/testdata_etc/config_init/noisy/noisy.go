@@ -0,0 +1,5 @@
+package noisy
+
+// TODO: excluded noisy issue 1
+// TODO: excluded noisy issue 2
+// TODO: excluded noisy issue 3
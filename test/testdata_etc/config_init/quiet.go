@@ -0,0 +1,3 @@
+package configinit
+
+// TODO: reported quiet issue
@@ -0,0 +1,3 @@
+package main
+
+func main() {} // TODO lint root module
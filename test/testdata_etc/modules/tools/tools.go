@@ -0,0 +1,3 @@
+package tools
+
+func Tools() {} // TODO lint listed module
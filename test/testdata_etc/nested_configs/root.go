@@ -0,0 +1,12 @@
+package nested
+
+// TODO lint root package
+
+func rootShadow(x int) int {
+	notShadowingByRootConfig := x
+	if x > 0 {
+		notShadowingByRootConfig := 2 * x
+		return notShadowingByRootConfig
+	}
+	return notShadowingByRootConfig
+}
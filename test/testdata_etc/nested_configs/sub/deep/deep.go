@@ -0,0 +1,4 @@
+package deep
+
+// FIXME lint deep package
+// FIXME excluded by deep config
@@ -0,0 +1,13 @@
+package sub
+
+// TODO not reported by sub config
+// FIXME lint sub package
+
+func subShadow(x int) int {
+	shadowingBySubConfig := x
+	if x > 0 {
+		shadowingBySubConfig := 2 * x
+		return shadowingBySubConfig
+	}
+	return shadowingBySubConfig
+}
@@ -72,6 +72,11 @@ func (r *RunResult) ExpectOutputContains(s string) *RunResult {
 	return r
 }
 
+func (r *RunResult) ExpectOutputNotContains(s string) *RunResult {
+	assert.NotContains(r.t, r.output, s, "exit code is %d", r.exitCode)
+	return r
+}
+
 func (r *RunResult) ExpectOutputEq(s string) *RunResult {
 	assert.Equal(r.t, s, r.output, "exit code is %d", r.exitCode)
 	return r
@@ -82,12 +87,17 @@ func (r *RunResult) ExpectHasIssue(issueText string) *RunResult {
 }
 
 func (r *LintRunner) Run(args ...string) *RunResult {
+	return r.RunWithStdin("", args...)
+}
+
+func (r *LintRunner) RunWithStdin(stdin string, args ...string) *RunResult {
 	r.Install()
 
 	runArgs := append([]string{"run"}, args...)
 	r.log.Infof("../golangci-lint %s", strings.Join(runArgs, " "))
 	cmd := exec.Command("../golangci-lint", runArgs...)
 	cmd.Env = append(os.Environ(), r.env...)
+	cmd.Stdin = strings.NewReader(stdin)
 	out, err := cmd.CombinedOutput()
 	if err != nil {
 		if exitError, ok := err.(*exec.ExitError); ok {